@@ -1,17 +1,20 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"sync"
 	"time"
 
 	"github.com/ethanolivertroy/fedramp-tui/internal/cache"
 	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+	"github.com/ethanolivertroy/fedramp-tui/internal/schema"
+	"github.com/ethanolivertroy/fedramp-tui/internal/snapshot"
 )
 
-const BaseURL = "https://raw.githubusercontent.com/FedRAMP/docs/main"
+// BaseURL is the location of the FRMR documents on the default branch
+const BaseURL = githubRawPrefix + Repository + "/" + DefaultRef
 
 // DocumentFiles maps document codes to their filenames
 var DocumentFiles = map[string]DocumentMetadata{
@@ -32,29 +35,81 @@ var DocumentFiles = map[string]DocumentMetadata{
 // DocumentOrder defines the display order of documents
 var DocumentOrder = []string{"FRD", "KSI", "VDR", "UCM", "RSC", "ADS", "CCM", "FSI", "ICP", "MAS", "PVA", "SCN"}
 
-// Client is an HTTP client for fetching FedRAMP documents
+// Client fetches FedRAMP documents from a Source, caching them locally
 type Client struct {
-	httpClient *http.Client
-	baseURL    string
-	cache      *cache.Cache
-	refresh    bool
+	source      Source
+	cache       *cache.Cache
+	refresh     bool
+	useSnapshot bool
+
+	// Retry policy
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	sleep       func(ctx context.Context, d time.Duration) error
+
+	// Documents available from the source, see Discover
+	mu        sync.RWMutex
+	documents []DocumentMetadata
+
+	// Declared JSON Schemas, fetched once per client
+	schemaMu   sync.Mutex
+	schemas    map[string]*schema.Schema
+	schemaErrs map[string]error // Why a schema could not be used, so it is not retried
 }
 
 // ClientOption configures the client
 type ClientOption func(*Client)
 
-// WithRefresh forces fresh fetch, ignoring cache
+// WithRefresh revalidates every cached document with its source now, instead
+// of waiting for the cache TTL to expire
 func WithRefresh(refresh bool) ClientOption {
 	return func(c *Client) {
 		c.refresh = refresh
 	}
 }
 
+// WithSource sets the backend documents are fetched from
+func WithSource(source Source) ClientOption {
+	return func(c *Client) {
+		c.source = source
+	}
+}
+
+// WithCache overrides the default on-disk cache (nil disables caching)
+func WithCache(cache *cache.Cache) ClientOption {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// WithSnapshot controls whether the embedded offline snapshot is used when a
+// document cannot be fetched or found in the cache
+func WithSnapshot(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.useSnapshot = enabled
+	}
+}
+
+// WithRetry sets how many times a fetch is attempted and the initial backoff
+// delay between attempts
+func WithRetry(maxAttempts int, baseDelay time.Duration) ClientOption {
+	return func(c *Client) {
+		c.maxAttempts = max(maxAttempts, 1)
+		c.baseDelay = baseDelay
+	}
+}
+
 // NewClient creates a new API client
 func NewClient(opts ...ClientOption) *Client {
 	c := &Client{
-		httpClient: &http.Client{Timeout: 60 * time.Second},
-		baseURL:    BaseURL,
+		source:      NewHTTPSource(BaseURL),
+		useSnapshot: true,
+		maxAttempts: DefaultMaxAttempts,
+		baseDelay:   DefaultBaseDelay,
+		maxDelay:    DefaultMaxDelay,
+		sleep:       sleepContext,
+		documents:   staticDocuments(),
 	}
 
 	// Initialize cache (ignore errors, will just fetch fresh)
@@ -69,42 +124,105 @@ func NewClient(opts ...ClientOption) *Client {
 	return c
 }
 
+// Origin describes where a document's data came from
+type Origin int
+
+const (
+	OriginSource      Origin = iota // Fetched from the configured source
+	OriginCache                     // Served from the local cache
+	OriginRevalidated               // Cached copy confirmed current by the source
+	OriginStale                     // Expired cache entry served because the source failed
+	OriginSnapshot                  // Served from the snapshot embedded in the binary
+)
+
+func (o Origin) String() string {
+	switch o {
+	case OriginCache:
+		return "cache"
+	case OriginRevalidated:
+		return "revalidated"
+	case OriginStale:
+		return "stale cache"
+	case OriginSnapshot:
+		return "snapshot"
+	default:
+		return "source"
+	}
+}
+
 // FetchResult holds the result of fetching a document
 type FetchResult struct {
-	Code  string
-	Data  []byte
-	Error error
+	Code      string
+	Data      []byte
+	Origin    Origin
+	FetchedAt time.Time // When the data was last fetched or revalidated from the source
+	Error     error
+	// RefreshError explains why an OriginStale document could not be refreshed
+	RefreshError error
 }
 
-// FetchAllDocuments fetches all documents in parallel
-func (c *Client) FetchAllDocuments() (map[string][]byte, error) {
-	results := make(map[string][]byte)
-	var mu sync.Mutex
+// Stale returns true if the data came from an expired cache entry
+func (r FetchResult) Stale() bool {
+	return r.Origin == OriginStale
+}
+
+// FetchAllResults fetches all known documents in parallel and reports, in
+// display order, where each one came from
+func (c *Client) FetchAllResults() []FetchResult {
+	return c.FetchAllResultsContext(context.Background())
+}
+
+// FetchAllResultsContext is like FetchAllResults but can be cancelled through ctx
+func (c *Client) FetchAllResultsContext(ctx context.Context) []FetchResult {
+	return c.FetchDocumentsContext(ctx, c.DocumentCodes())
+}
+
+// FetchDocuments fetches the given document codes in parallel, returning
+// results in the same order
+func (c *Client) FetchDocuments(codes []string) []FetchResult {
+	return c.FetchDocumentsContext(context.Background(), codes)
+}
+
+// FetchDocumentsContext is like FetchDocuments but can be cancelled through ctx
+func (c *Client) FetchDocumentsContext(ctx context.Context, codes []string) []FetchResult {
+	results := make([]FetchResult, len(codes))
 	var wg sync.WaitGroup
-	errChan := make(chan error, len(DocumentFiles))
 
-	for code, meta := range DocumentFiles {
+	for i, code := range codes {
 		wg.Add(1)
-		go func(code string, meta DocumentMetadata) {
+		go func(i int, code string) {
 			defer wg.Done()
-			data, err := c.fetchDocument(meta.Filename)
-			if err != nil {
-				errChan <- fmt.Errorf("fetching %s: %w", code, err)
+			meta, ok := c.metadata(code)
+			if !ok {
+				results[i] = FetchResult{Code: code, Error: fmt.Errorf("unknown document %s", code)}
 				return
 			}
-			mu.Lock()
-			results[code] = data
-			mu.Unlock()
-		}(code, meta)
+			res := c.fetch(ctx, meta.Filename)
+			res.Code = code
+			results[i] = res
+		}(i, code)
 	}
 
 	wg.Wait()
-	close(errChan)
+	return results
+}
 
-	// Collect any errors
+// FetchAllDocuments fetches all documents in parallel
+func (c *Client) FetchAllDocuments() (map[string][]byte, error) {
+	return c.FetchAllDocumentsContext(context.Background())
+}
+
+// FetchAllDocumentsContext is like FetchAllDocuments but can be cancelled through ctx
+func (c *Client) FetchAllDocumentsContext(ctx context.Context) (map[string][]byte, error) {
+	results := make(map[string][]byte)
 	var errs []error
-	for err := range errChan {
-		errs = append(errs, err)
+
+	for _, res := range c.FetchAllResultsContext(ctx) {
+		if res.Error != nil {
+			errs = append(errs, fmt.Errorf("fetching %s: %w", res.Code, res.Error))
+			continue
+		}
+		results[res.Code] = res.Data
 	}
 
 	if len(errs) > 0 {
@@ -114,45 +232,117 @@ func (c *Client) FetchAllDocuments() (map[string][]byte, error) {
 	return results, nil
 }
 
+// SnapshotRelease returns the release label of the embedded snapshot
+func (c *Client) SnapshotRelease() string {
+	return snapshot.Load().Release
+}
+
 func (c *Client) fetchDocument(filename string) ([]byte, error) {
-	url := c.baseURL + "/" + filename
+	res := c.fetch(context.Background(), filename)
+	return res.Data, res.Error
+}
+
+func (c *Client) fetch(ctx context.Context, filename string) FetchResult {
+	key := c.source.Location(filename)
 
-	// Check cache first (unless refresh is forced)
-	if c.cache != nil && !c.refresh {
-		if data, ok := c.cache.Get(url); ok {
-			return data, nil
+	// Serve fresh cache entries directly (unless refresh is forced)
+	var entry *cache.Entry
+	if c.cache != nil {
+		entry, _ = c.cache.Lookup(key)
+		if entry != nil && !c.refresh && (c.pinnedCommit() || !entry.Expired(c.cache.TTL)) {
+			return FetchResult{Data: entry.Data, Origin: OriginCache, FetchedAt: entry.FetchedAt}
 		}
 	}
 
-	// Fetch from network
-	resp, err := c.httpClient.Get(url)
+	resp, err := c.fetchWithRetry(ctx, filename, entry)
 	if err != nil {
-		return nil, err
+		// Nothing to fall back to when the caller gave up
+		if ctx.Err() != nil {
+			return FetchResult{Error: err}
+		}
+		// Prefer an expired copy of our own over failing outright
+		if entry != nil {
+			return FetchResult{Data: entry.Data, Origin: OriginStale, FetchedAt: entry.FetchedAt, RefreshError: err}
+		}
+		// Fall back to the embedded snapshot when offline
+		if c.useSnapshot && c.snapshotUsable() {
+			if data, ok := snapshot.Get(filename); ok {
+				return FetchResult{Data: data, Origin: OriginSnapshot}
+			}
+		}
+		return FetchResult{Error: err}
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+	meta := cache.Metadata{
+		ETag:         resp.ETag,
+		LastModified: resp.LastModified,
+		FetchedAt:    time.Now(),
+		SourceURL:    key,
+		Ref:          c.Ref(),
 	}
 
-	var data []byte
-	buf := make([]byte, 1024)
-	for {
-		n, err := resp.Body.Read(buf)
-		if n > 0 {
-			data = append(data, buf[:n]...)
+	// Unchanged upstream: renew the cached copy without downloading it again
+	if resp.NotModified && entry != nil {
+		if meta.ETag == "" {
+			meta.ETag = entry.ETag
 		}
-		if err != nil {
-			break
+		if meta.LastModified == "" {
+			meta.LastModified = entry.LastModified
 		}
+		_ = c.cache.Renew(key, meta)
+		return FetchResult{Data: entry.Data, Origin: OriginRevalidated, FetchedAt: meta.FetchedAt}
 	}
 
 	// Save to cache
 	if c.cache != nil {
-		_ = c.cache.Set(url, data)
+		_ = c.cache.Put(key, resp.Data, meta)
+	}
+
+	return FetchResult{Data: resp.Data, Origin: OriginSource, FetchedAt: meta.FetchedAt}
+}
+
+// fetchWithRetry fetches a file from the source, retrying transient failures
+// with jittered exponential backoff and honoring rate limit delays
+func (c *Client) fetchWithRetry(ctx context.Context, filename string, entry *cache.Entry) (*Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.fetchFromSource(ctx, filename, entry)
+		if err == nil {
+			return resp, nil
+		}
+		delay, ok := c.retryDelay(err, attempt)
+		if !ok {
+			return nil, err
+		}
+		if err := c.sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// fetchFromSource fetches a file, revalidating the cached entry when the
+// source supports conditional requests
+func (c *Client) fetchFromSource(ctx context.Context, filename string, entry *cache.Entry) (*Response, error) {
+	cs, ok := c.source.(ConditionalSource)
+	if !ok {
+		data, err := c.source.Fetch(ctx, filename)
+		if err != nil {
+			return nil, err
+		}
+		return &Response{Data: data}, nil
 	}
 
-	return data, nil
+	var etag, lastModified string
+	if entry != nil {
+		etag, lastModified = entry.ETag, entry.LastModified
+	}
+	resp, err := cs.FetchConditional(ctx, filename, etag, lastModified)
+	if err != nil {
+		return nil, err
+	}
+	if resp.NotModified && entry == nil {
+		return nil, fmt.Errorf("%s: unexpected 304 Not Modified without a cached copy", filename)
+	}
+	return resp, nil
 }
 
 // ParseDefinitions parses the FRD document into Definition models
@@ -202,15 +392,16 @@ func (c *Client) ParseIndicators(data []byte) ([]model.Indicator, error) {
 		return nil, fmt.Errorf("KSI section not found in document")
 	}
 
-	var ksiThemes map[string]ThemeJSON
-	if err := json.Unmarshal(ksiRaw, &ksiThemes); err != nil {
+	themeCodes, ksiThemes, err := decodeOrdered[ThemeJSON](ksiRaw)
+	if err != nil {
 		return nil, fmt.Errorf("parsing KSI themes: %w", err)
 	}
 
 	var indicators []model.Indicator
 
-	// Parse the KSI themes
-	for themeCode, theme := range ksiThemes {
+	// Parse the KSI themes in document order
+	for _, themeCode := range themeCodes {
+		theme := ksiThemes[themeCode]
 		for _, ind := range theme.Indicators {
 			controls := make([]model.Control, len(ind.Controls))
 			for j, ctrl := range ind.Controls {
@@ -221,12 +412,12 @@ func (c *Client) ParseIndicators(data []byte) ([]model.Indicator, error) {
 			}
 
 			indicators = append(indicators, model.Indicator{
-				ID:           ind.ID,
-				ThemeCode:    themeCode,
-				ThemeName:    theme.Name,
-				ThemeDesc:    theme.Theme,
-				Name:         ind.Name,
-				Statement:    ind.Statement,
+				ID:        ind.ID,
+				ThemeCode: themeCode,
+				ThemeName: theme.Name,
+				ThemeDesc: theme.Theme,
+				Name:      ind.Name,
+				Statement: ind.Statement,
 				Impact: model.Impact{
 					Low:      ind.Impact.Low,
 					Moderate: ind.Impact.Moderate,
@@ -251,36 +442,55 @@ func (c *Client) ParseRequirements(data []byte, docCode string) ([]model.Require
 		return nil, err
 	}
 
-	var requirements []model.Requirement
+	frrData, ok := rawDoc["FRR"]
+	if !ok {
+		return nil, fmt.Errorf("FRR section not found in document")
+	}
+	var frr map[string]json.RawMessage
+	if err := json.Unmarshal(frrData, &frr); err != nil {
+		return nil, fmt.Errorf("parsing FRR section: %w", err)
+	}
 
-	// Parse FRR section if present
-	if frrData, ok := rawDoc["FRR"]; ok {
-		var frr map[string]json.RawMessage
-		if err := json.Unmarshal(frrData, &frr); err != nil {
-			return nil, err
-		}
+	// Look for the document's section
+	docData, ok := frr[docCode]
+	if !ok {
+		return nil, fmt.Errorf("FRR.%s section not found in document", docCode)
+	}
 
-		// Look for the document's section
-		if docData, ok := frr[docCode]; ok {
-			var categories map[string]RequirementCategory
-			if err := json.Unmarshal(docData, &categories); err != nil {
-				// Try as a single category
-				var singleCat RequirementCategory
-				if err2 := json.Unmarshal(docData, &singleCat); err2 == nil {
-					requirements = append(requirements, c.extractRequirements(singleCat.Requirements, docCode)...)
-				}
-			} else {
-				for _, cat := range categories {
-					requirements = append(requirements, c.extractRequirements(cat.Requirements, docCode)...)
-				}
-			}
+	var requirements []model.Requirement
+	categoryIDs, categories, err := decodeOrdered[RequirementCategory](docData)
+	if err != nil {
+		// Try as a single category
+		var singleCat RequirementCategory
+		if err2 := json.Unmarshal(docData, &singleCat); err2 != nil {
+			return nil, fmt.Errorf("parsing FRR.%s: %w", docCode, err)
+		}
+		requirements = append(requirements, c.extractRequirements(singleCat.Requirements, docCode, newCategory(singleCat, ""), "")...)
+	} else {
+		for _, id := range categoryIDs {
+			cat := categories[id]
+			requirements = append(requirements, c.extractRequirements(cat.Requirements, docCode, newCategory(cat, id), "")...)
 		}
 	}
 
 	return requirements, nil
 }
 
-func (c *Client) extractRequirements(reqs []RequirementJSON, docCode string) []model.Requirement {
+// newCategory converts a category, identified by its key in the FRR section
+// when it does not carry its own ID
+func newCategory(cat RequirementCategory, key string) model.Category {
+	id := cat.ID
+	if id == "" {
+		id = key
+	}
+	return model.Category{ID: id, Name: cat.Name, Application: cat.Application}
+}
+
+// extractRequirements converts requirements and, recursively, their nested
+// following_information requirements. Each requirement is followed by its
+// sub-requirements, which link back to it through ParentID. Sub-requirements
+// without an ID are numbered after their parent, e.g. FRR-VDR-01.2.
+func (c *Client) extractRequirements(reqs []RequirementJSON, docCode string, category model.Category, parentID string) []model.Requirement {
 	var requirements []model.Requirement
 
 	for i := range reqs {
@@ -288,26 +498,44 @@ func (c *Client) extractRequirements(reqs []RequirementJSON, docCode string) []m
 		// Process the following_information field which may be string or array
 		r.UnmarshalFollowingInfo()
 
+		id := r.ID
+		if id == "" && parentID != "" {
+			id = fmt.Sprintf("%s.%d", parentID, i+1)
+		}
+
+		// Encode as [] rather than null, so structured output keeps one shape
+		affects := r.Affects
+		if affects == nil {
+			affects = []string{}
+		}
+
 		req := model.Requirement{
-			ID:             r.ID,
-			DocumentCode:   docCode,
-			Statement:      r.Statement,
-			Name:           r.Name,
+			ID:           id,
+			DocumentCode: docCode,
+			Category:     category,
+			Statement:    r.Statement,
+			Name:         r.Name,
 			Impact: model.Impact{
 				Low:      r.Impact.Low,
 				Moderate: r.Impact.Moderate,
 				High:     r.Impact.High,
 			},
-			Affects:        r.Affects,
+			Affects:        affects,
 			PrimaryKeyWord: r.PrimaryKeyWord,
 			Note:           r.Note,
+			ParentID:       parentID,
+			Following:      r.FollowingText,
 		}
-		requirements = append(requirements, req)
 
 		// Also extract nested requirements
-		if len(r.FollowingInformation) > 0 {
-			requirements = append(requirements, c.extractRequirements(r.FollowingInformation, docCode)...)
+		children := c.extractRequirements(r.FollowingInformation, docCode, category, id)
+		for _, child := range children {
+			if child.ParentID == id {
+				req.Children = append(req.Children, child.ID)
+			}
 		}
+		requirements = append(requirements, req)
+		requirements = append(requirements, children...)
 	}
 
 	return requirements
@@ -344,6 +572,17 @@ func EnrichDocument(doc *model.Document, info *DocumentInfo) {
 		return
 	}
 
+	// Documents discovered upstream are named from their info section
+	if doc.Name == "" {
+		doc.Name = info.Name
+		if doc.Name == "" {
+			doc.Name = doc.Code
+		}
+	}
+	if doc.Description == "" {
+		doc.Description = info.ShortName
+	}
+
 	// Purpose and expected outcomes
 	doc.Purpose = info.FrontMatter.Purpose
 	doc.ExpectedOutcomes = info.FrontMatter.ExpectedOutcomes
@@ -351,33 +590,47 @@ func EnrichDocument(doc *model.Document, info *DocumentInfo) {
 	// Authority references
 	for _, auth := range info.FrontMatter.Authority {
 		doc.Authority = append(doc.Authority, model.Authority{
-			Reference:    auth.Reference,
-			ReferenceURL: auth.ReferenceURL,
-			Description:  auth.Description,
+			Reference:     auth.Reference,
+			ReferenceURL:  auth.ReferenceURL,
+			Description:   auth.Description,
+			Delegation:    auth.Delegation,
+			DelegationURL: auth.DelegationURL,
 		})
 	}
 
-	// Releases
+	// Releases and the RFCs related to them
 	for _, rel := range info.Releases {
-		doc.Releases = append(doc.Releases, model.Release{
+		release := model.Release{
 			ID:            rel.ID,
 			PublishedDate: rel.PublishedDate,
 			Description:   rel.Description,
-		})
+			PublicComment: rel.PublicComment,
+		}
+		for _, rfc := range rel.RelatedRFCs {
+			release.RelatedRFCs = append(release.RelatedRFCs, model.RFC{
+				ID:            rfc.ID,
+				URL:           rfc.URL,
+				DiscussionURL: rfc.DiscussionURL,
+				ShortName:     rfc.ShortName,
+				FullName:      rfc.FullName,
+				StartDate:     rfc.StartDate,
+				EndDate:       rfc.EndDate,
+			})
+		}
+		doc.Releases = append(doc.Releases, release)
 	}
 
-	// Effective info (program status)
-	if len(info.Effective) > 0 {
-		doc.EffectiveInfo = make(map[string]model.EffectiveStatus)
-		for version, eff := range info.Effective {
-			doc.EffectiveInfo[version] = model.EffectiveStatus{
-				Is:            eff.Is,
-				CurrentStatus: eff.CurrentStatus,
-				StartDate:     eff.StartDate,
-				EndDate:       eff.EndDate,
-				SignupURL:     eff.SignupURL,
-				Comments:      eff.Comments,
-			}
-		}
+	// Effective info (program status), in document order
+	for _, eff := range info.Effective {
+		doc.EffectiveInfo = append(doc.EffectiveInfo, model.EffectiveStatus{
+			Version:       eff.Version,
+			Is:            eff.Is,
+			CurrentStatus: eff.CurrentStatus,
+			StartDate:     eff.StartDate,
+			EndDate:       eff.EndDate,
+			SignupURL:     eff.SignupURL,
+			Comments:      eff.Comments,
+			Warnings:      eff.Warnings,
+		})
 	}
 }
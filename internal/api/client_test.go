@@ -2,7 +2,11 @@ package api
 
 import (
 	"encoding/json"
+	"reflect"
+	"slices"
 	"testing"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/model"
 )
 
 func TestParseKSIStructure(t *testing.T) {
@@ -170,3 +174,144 @@ func TestParseFSIRequirements(t *testing.T) {
 		t.Logf("Requirement %d: %s - %s (keyword: %s)", i, req.ID, req.Name, req.PrimaryKeyWord)
 	}
 }
+
+func TestParseRequirementCategories(t *testing.T) {
+	data := []byte(`{"FRR": {"VDR": {
+		"base": {"id": "base", "name": "Base Requirements", "application": "These apply to all providers.", "requirements": [
+			{"id": "FRR-VDR-01", "statement": "Parent", "following_information": [{"id": "FRR-VDR-01-a", "statement": "Child"}]}
+		]},
+		"tr": {"application": "Transitional providers only.", "requirements": [{"id": "FRR-VDR-TR-01"}]}
+	}}}`)
+
+	reqs, err := NewClient(WithCache(nil)).ParseRequirements(data, "VDR")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byID := make(map[string]model.Category)
+	for _, r := range reqs {
+		byID[r.ID] = r.Category
+	}
+	base := model.Category{ID: "base", Name: "Base Requirements", Application: "These apply to all providers."}
+	if byID["FRR-VDR-01"] != base || byID["FRR-VDR-01-a"] != base {
+		t.Errorf("Expected base category on parent and child, got %+v", byID)
+	}
+	// Categories without an ID are identified by their key
+	if got := byID["FRR-VDR-TR-01"]; got.ID != "tr" || got.Label() != "tr" || got.Application != "Transitional providers only." {
+		t.Errorf("Unexpected category %+v", got)
+	}
+}
+
+func TestParseRequirementHierarchy(t *testing.T) {
+	data := []byte(`{"FRR": {"VDR": {"base": {"requirements": [
+		{"id": "FRR-VDR-01", "statement": "Providers MUST include the following:", "following_information": [
+			{"id": "FRR-VDR-01-a", "statement": "An inventory", "following_information": [{"statement": "Unnamed item"}]},
+			"A plain text item",
+			{"id": "FRR-VDR-01-b", "statement": "A schedule"}
+		]},
+		{"id": "FRR-VDR-02", "statement": "Providers SHOULD consider:", "following_information": "Any single string"}
+	]}}}}`)
+
+	reqs, err := NewClient(WithCache(nil)).ParseRequirements(data, "VDR")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	byID := make(map[string]model.Requirement)
+	for _, r := range reqs {
+		ids = append(ids, r.ID)
+		byID[r.ID] = r
+	}
+	// Each requirement is followed by its sub-requirements
+	wantIDs := []string{"FRR-VDR-01", "FRR-VDR-01-a", "FRR-VDR-01-a.1", "FRR-VDR-01-b", "FRR-VDR-02"}
+	if !slices.Equal(ids, wantIDs) {
+		t.Fatalf("Expected %v, got %v", wantIDs, ids)
+	}
+
+	parent := byID["FRR-VDR-01"]
+	if !slices.Equal(parent.Children, []string{"FRR-VDR-01-a", "FRR-VDR-01-b"}) {
+		t.Errorf("Unexpected children %v", parent.Children)
+	}
+	if !slices.Equal(parent.Following, []string{"A plain text item"}) {
+		t.Errorf("Unexpected following text %v", parent.Following)
+	}
+	if byID["FRR-VDR-01-a"].ParentID != "FRR-VDR-01" || byID["FRR-VDR-01-a.1"].ParentID != "FRR-VDR-01-a" {
+		t.Error("Expected sub-requirements to link to their parent")
+	}
+	if byID["FRR-VDR-02"].ParentID != "" || !slices.Equal(byID["FRR-VDR-02"].Following, []string{"Any single string"}) {
+		t.Errorf("Unexpected top-level requirement %+v", byID["FRR-VDR-02"])
+	}
+	if byID["FRR-VDR-01-a"].Affects == nil {
+		t.Error("Expected missing affects to be empty rather than nil")
+	}
+}
+
+func TestEnrichDocumentRFCs(t *testing.T) {
+	data := []byte(`{"info": {"name": "VDR", "short_name": "VDR", "releases": [
+		{"id": "25.09A", "published_date": "2025-09-01", "public_comment": true, "related_rfcs": [
+			{"id": "RFC-0012", "url": "https://www.fedramp.gov/rfcs/0012/", "discussion_url": "https://github.com/FedRAMP/community/discussions/1",
+			 "short_name": "VDR", "full_name": "Vulnerability Detection and Response Standard", "start_date": "2025-08-01", "end_date": "2025-08-31"}
+		]},
+		{"id": "25.06A", "published_date": "2025-06-01"}
+	]}}`)
+
+	info, err := NewClient(WithCache(nil)).ParseDocumentInfo(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc model.Document
+	EnrichDocument(&doc, info)
+
+	if len(doc.Releases) != 2 || !doc.Releases[0].PublicComment || doc.Releases[1].PublicComment {
+		t.Fatalf("Unexpected releases %+v", doc.Releases)
+	}
+	rfcs := doc.Releases[0].RelatedRFCs
+	if len(rfcs) != 1 {
+		t.Fatalf("Expected 1 related RFC, got %d", len(rfcs))
+	}
+	want := model.RFC{
+		ID:            "RFC-0012",
+		URL:           "https://www.fedramp.gov/rfcs/0012/",
+		DiscussionURL: "https://github.com/FedRAMP/community/discussions/1",
+		ShortName:     "VDR",
+		FullName:      "Vulnerability Detection and Response Standard",
+		StartDate:     "2025-08-01",
+		EndDate:       "2025-08-31",
+	}
+	if !reflect.DeepEqual(rfcs[0], want) {
+		t.Errorf("Expected %+v, got %+v", want, rfcs[0])
+	}
+}
+
+func TestEnrichDocumentWarningsAndDelegations(t *testing.T) {
+	data := []byte(`{"info": {"name": "ADS", "short_name": "ADS",
+		"effective": {
+			"20x": {"is": "required", "warnings": ["Phase One pilot has ended"]},
+			"rev5": {"is": "optional"}
+		},
+		"front_matter": {"authority": [
+			{"reference": "OMB M-24-15", "delegation": "FedRAMP PMO", "delegation_url": "https://example.gov/delegation"}
+		]}
+	}}`)
+
+	info, err := NewClient(WithCache(nil)).ParseDocumentInfo(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc model.Document
+	EnrichDocument(&doc, info)
+
+	if len(doc.EffectiveInfo) != 2 {
+		t.Fatalf("Expected 2 effective versions, got %d", len(doc.EffectiveInfo))
+	}
+	if got := doc.Warnings(); !slices.Equal(got, []string{"20x: Phase One pilot has ended"}) {
+		t.Errorf("Unexpected warnings %v", got)
+	}
+	if len(doc.Authority) != 1 {
+		t.Fatalf("Expected 1 authority, got %d", len(doc.Authority))
+	}
+	if auth := doc.Authority[0]; auth.Delegation != "FedRAMP PMO" || auth.DelegationURL != "https://example.gov/delegation" {
+		t.Errorf("Delegation not carried through: %+v", auth)
+	}
+}
@@ -0,0 +1,289 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/cache"
+	"github.com/ethanolivertroy/fedramp-tui/internal/snapshot"
+)
+
+// ManifestFilename is the index file listing the documents a source provides.
+// It is used by mirrors that cannot be listed any other way, and takes
+// precedence over a directory listing when present.
+const ManifestFilename = "frmr-manifest.json"
+
+// Manifest is the contents of a manifest file
+type Manifest struct {
+	Files []string `json:"files"`
+}
+
+// documentFilename matches FRMR.<CODE>.<slug>.json
+var documentFilename = regexp.MustCompile(`^FRMR\.([A-Z0-9]+)\.([a-z0-9-]+)\.json$`)
+
+// ParseDocumentFilename returns the document code of an FRMR filename
+func ParseDocumentFilename(name string) (code string, ok bool) {
+	m := documentFilename.FindStringSubmatch(name)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// Lister is implemented by sources that can enumerate the files they provide
+type Lister interface {
+	List(ctx context.Context) ([]string, error)
+}
+
+// githubRawPrefix is the base of raw.githubusercontent.com URLs
+const githubRawPrefix = "https://raw.githubusercontent.com/"
+
+// contentsURLFor derives the GitHub contents API URL for a raw.githubusercontent.com base URL
+func contentsURLFor(baseURL string) string {
+	repo, ref, ok := parseGitHubRaw(baseURL)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("https://api.github.com/repos/%s/contents?ref=%s", repo, url.QueryEscape(ref))
+}
+
+// List enumerates documents through the GitHub contents API when the base URL
+// points at GitHub, and through the manifest file otherwise
+func (s *HTTPSource) List(ctx context.Context) ([]string, error) {
+	if s.ContentsURL == "" {
+		data, err := s.Fetch(ctx, ManifestFilename)
+		if err != nil {
+			return nil, err
+		}
+		return parseManifest(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.ContentsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError(resp)
+	}
+
+	var entries []struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 10<<20)).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("parsing contents listing: %w", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.Type == "" || e.Type == "file" {
+			names = append(names, e.Name)
+		}
+	}
+	return names, nil
+}
+
+// List returns the files named in the directory's manifest, or every file in
+// the directory when there is no manifest
+func (s *DirSource) List(ctx context.Context) ([]string, error) {
+	if data, err := s.Fetch(ctx, ManifestFilename); err == nil {
+		return parseManifest(data)
+	}
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+// List returns every FRMR file in the archive
+func (s *ArchiveSource) List(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.once.Do(s.load)
+	if s.err != nil {
+		return nil, s.err
+	}
+	names := make([]string, 0, len(s.files))
+	for name := range s.files {
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func parseManifest(data []byte) ([]string, error) {
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", ManifestFilename, err)
+	}
+	return m.Files, nil
+}
+
+// documentsFromFiles builds document metadata from a file listing. Known
+// codes keep their curated names and display order; unknown codes follow in
+// alphabetical order and are named from their info section once loaded.
+func documentsFromFiles(files []string) []DocumentMetadata {
+	found := make(map[string]DocumentMetadata)
+	for _, name := range files {
+		code, ok := ParseDocumentFilename(name)
+		if !ok {
+			continue
+		}
+		meta, known := DocumentFiles[code]
+		if !known {
+			meta = DocumentMetadata{Code: code}
+		}
+		meta.Filename = name
+		found[code] = meta
+	}
+
+	docs := make([]DocumentMetadata, 0, len(found))
+	for _, code := range DocumentOrder {
+		if meta, ok := found[code]; ok {
+			docs = append(docs, meta)
+			delete(found, code)
+		}
+	}
+	var unknown []string
+	for code := range found {
+		unknown = append(unknown, code)
+	}
+	sort.Strings(unknown)
+	for _, code := range unknown {
+		docs = append(docs, found[code])
+	}
+	return docs
+}
+
+// staticDocuments returns the built-in document list
+func staticDocuments() []DocumentMetadata {
+	docs := make([]DocumentMetadata, 0, len(DocumentOrder))
+	for _, code := range DocumentOrder {
+		docs = append(docs, DocumentFiles[code])
+	}
+	return docs
+}
+
+// Discover lists the documents available from the source and uses them for
+// subsequent fetches. When the source cannot be listed, the last listing in
+// the cache, the embedded snapshot's file list, or the built-in document list
+// is used instead, and the listing error is returned.
+func (c *Client) Discover() error {
+	return c.DiscoverContext(context.Background())
+}
+
+// DiscoverContext is like Discover but can be cancelled through ctx
+func (c *Client) DiscoverContext(ctx context.Context) error {
+	files, err := c.listDocuments(ctx)
+	if err == nil {
+		if docs := documentsFromFiles(files); len(docs) > 0 {
+			c.setDocuments(docs)
+			return nil
+		}
+		err = errors.New("no FRMR documents found")
+	}
+
+	if c.cache != nil {
+		if entry, ok := c.cache.Lookup(c.indexKey()); ok {
+			var cached []string
+			if json.Unmarshal(entry.Data, &cached) == nil {
+				if docs := documentsFromFiles(cached); len(docs) > 0 {
+					c.setDocuments(docs)
+					return fmt.Errorf("listing documents: %w", err)
+				}
+			}
+		}
+	}
+
+	if c.useSnapshot && snapshot.Available() {
+		c.setDocuments(documentsFromFiles(snapshot.Load().Files))
+	} else {
+		c.setDocuments(staticDocuments())
+	}
+	return fmt.Errorf("listing documents: %w", err)
+}
+
+// listDocuments lists the source and caches the result for offline use
+func (c *Client) listDocuments(ctx context.Context) ([]string, error) {
+	lister, ok := c.source.(Lister)
+	if !ok {
+		return nil, errors.New("source cannot list documents")
+	}
+
+	files, err := lister.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.cache != nil {
+		if data, err := json.Marshal(files); err == nil {
+			_ = c.cache.Put(c.indexKey(), data, cache.Metadata{FetchedAt: time.Now(), SourceURL: c.indexKey()})
+		}
+	}
+	return files, nil
+}
+
+// indexKey is the cache key of the source's document listing
+func (c *Client) indexKey() string {
+	return "index:" + c.source.Location("")
+}
+
+func (c *Client) setDocuments(docs []DocumentMetadata) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.documents = docs
+}
+
+// Documents returns metadata for every known document, in display order
+func (c *Client) Documents() []DocumentMetadata {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]DocumentMetadata(nil), c.documents...)
+}
+
+// DocumentCodes returns the codes of every known document, in display order
+func (c *Client) DocumentCodes() []string {
+	docs := c.Documents()
+	codes := make([]string, len(docs))
+	for i, d := range docs {
+		codes[i] = d.Code
+	}
+	return codes
+}
+
+// metadata returns the metadata for a document code
+func (c *Client) metadata(code string) (DocumentMetadata, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, d := range c.documents {
+		if d.Code == code {
+			return d, true
+		}
+	}
+	return DocumentMetadata{}, false
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/cache"
+)
+
+const newDocumentFile = "FRMR.XYZ.new-requirements.json"
+
+// newDocument is an FRMR document with a code the client does not know about
+var newDocument = []byte(`{
+	"info": {"name": "New Requirements", "short_name": "XYZ"},
+	"FRR": {"XYZ": {"base": {"id": "base", "requirements": [
+		{"id": "FRR-XYZ-01", "name": "First", "statement": "Providers MUST do the new thing.", "primary_key_word": "MUST"}
+	]}}}
+}`)
+
+// newFakeGitHub serves raw files and a contents API listing for them
+func newFakeGitHub(t *testing.T) (*HTTPSource, *bool) {
+	t.Helper()
+	dir := writeFixtureDir(t)
+	if err := os.WriteFile(filepath.Join(dir, newDocumentFile), newDocument, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	listingUp := true
+	mux := http.NewServeMux()
+	mux.Handle("/raw/", http.StripPrefix("/raw/", http.FileServer(http.Dir(dir))))
+	mux.HandleFunc("/contents", func(w http.ResponseWriter, r *http.Request) {
+		if !listingUp {
+			http.Error(w, "unavailable", http.StatusBadGateway)
+			return
+		}
+		entries := []map[string]string{
+			{"name": "README.md", "type": "file"},
+			{"name": "markdown", "type": "dir"},
+		}
+		files, _ := os.ReadDir(dir)
+		for _, f := range files {
+			entries = append(entries, map[string]string{"name": f.Name(), "type": "file"})
+		}
+		_ = json.NewEncoder(w).Encode(entries)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	src := NewHTTPSource(srv.URL + "/raw")
+	src.ContentsURL = srv.URL + "/contents"
+	return src, &listingUp
+}
+
+func TestDiscoverFromGitHubContents(t *testing.T) {
+	src, _ := newFakeGitHub(t)
+	client := NewClient(WithSource(src), WithCache(&cache.Cache{Dir: t.TempDir(), TTL: time.Hour}), WithSnapshot(false))
+
+	if err := client.Discover(); err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+
+	codes := client.DocumentCodes()
+	want := append(slices.Clone(DocumentOrder), "XYZ")
+	if !slices.Equal(codes, want) {
+		t.Errorf("Expected codes %v, got %v", want, codes)
+	}
+
+	results := client.Load([]string{"XYZ"})
+	res := results[0]
+	if res.Failed() {
+		t.Fatalf("Loading XYZ failed: %v %v", res.Error, res.ParseError)
+	}
+	if res.Document.Name != "New Requirements" || res.Document.Description != "XYZ" {
+		t.Errorf("Expected metadata from info section, got %+v", res.Document)
+	}
+	if len(res.Requirements) != 1 || res.Requirements[0].ID != "FRR-XYZ-01" {
+		t.Errorf("Expected XYZ to be parsed as a requirements document, got %+v", res.Requirements)
+	}
+}
+
+func TestDiscoverFallsBackToCachedListing(t *testing.T) {
+	src, listingUp := newFakeGitHub(t)
+	c := &cache.Cache{Dir: t.TempDir(), TTL: time.Hour}
+
+	if err := NewClient(WithSource(src), WithCache(c), WithSnapshot(false)).Discover(); err != nil {
+		t.Fatal(err)
+	}
+
+	*listingUp = false
+	client := NewClient(WithSource(src), WithCache(c), WithSnapshot(false))
+	if err := client.Discover(); err == nil {
+		t.Error("Expected listing error to be reported")
+	}
+	if !slices.Contains(client.DocumentCodes(), "XYZ") {
+		t.Error("Expected the cached listing to be used")
+	}
+
+	// Without a cached listing the built-in documents are used
+	client = NewClient(WithSource(src), WithCache(nil), WithSnapshot(false))
+	_ = client.Discover()
+	if !slices.Equal(client.DocumentCodes(), DocumentOrder) {
+		t.Errorf("Expected built-in documents, got %v", client.DocumentCodes())
+	}
+}
+
+func TestDirSourceListing(t *testing.T) {
+	dir := writeFixtureDir(t)
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	src, _ := NewDirSource(dir)
+	client := NewClient(WithSource(src), WithCache(nil))
+	if err := client.Discover(); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(client.DocumentCodes(), DocumentOrder) {
+		t.Errorf("Expected all fixture documents, got %v", client.DocumentCodes())
+	}
+
+	// A manifest restricts the listing
+	manifest := `{"files": ["` + DocumentFiles["VDR"].Filename + `", "` + DocumentFiles["FRD"].Filename + `"]}`
+	if err := os.WriteFile(filepath.Join(dir, ManifestFilename), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Discover(); err != nil {
+		t.Fatal(err)
+	}
+	if got := client.DocumentCodes(); !slices.Equal(got, []string{"FRD", "VDR"}) {
+		t.Errorf("Expected manifest documents in display order, got %v", got)
+	}
+}
+
+func TestContentsURLFor(t *testing.T) {
+	tests := map[string]string{
+		BaseURL:                                 "https://api.github.com/repos/FedRAMP/docs/contents?ref=main",
+		githubRawPrefix + "FedRAMP/docs/v1.2.0": "https://api.github.com/repos/FedRAMP/docs/contents?ref=v1.2.0",
+		"https://mirror.example.com/docs":       "",
+	}
+	for base, want := range tests {
+		if got := contentsURLFor(base); got != want {
+			t.Errorf("contentsURLFor(%q) = %q, want %q", base, got, want)
+		}
+	}
+}
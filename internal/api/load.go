@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+)
+
+// DocumentResult is a fetched document together with its parsed contents
+type DocumentResult struct {
+	FetchResult
+	Document     model.Document // Metadata, enriched from the info section when parsed
+	Info         *DocumentInfo
+	Requirements []model.Requirement
+	Definitions  []model.Definition
+	Indicators   []model.Indicator
+	ParseError   error      // Set when the document was fetched but could not be parsed
+	Validation   Validation // Schema violations found in the fetched document
+}
+
+// Failed returns true if the document could not be fetched or parsed
+func (r DocumentResult) Failed() bool {
+	return r.Error != nil || r.ParseError != nil
+}
+
+// Load fetches the given documents in parallel and parses each one according
+// to its type. Results are returned in the same order as codes.
+func (c *Client) Load(codes []string) []DocumentResult {
+	return c.LoadContext(context.Background(), codes)
+}
+
+// LoadContext is like Load but can be cancelled through ctx
+func (c *Client) LoadContext(ctx context.Context, codes []string) []DocumentResult {
+	fetched := c.FetchDocumentsContext(ctx, codes)
+	results := make([]DocumentResult, len(fetched))
+	for i, res := range fetched {
+		results[i] = c.parseDocument(ctx, res)
+	}
+	return results
+}
+
+// newDocument returns the model document for a code, before enrichment
+func (c *Client) newDocument(code string) model.Document {
+	meta, ok := c.metadata(code)
+	if !ok {
+		meta = DocumentMetadata{Code: code}
+	}
+	return model.Document{
+		Code:        code,
+		Name:        meta.Name,
+		Description: meta.Description,
+	}
+}
+
+// parseDocument parses a fetched document; FRD holds definitions, KSI holds
+// indicators and requirements, and everything else holds requirements
+func (c *Client) parseDocument(ctx context.Context, res FetchResult) DocumentResult {
+	result := DocumentResult{FetchResult: res, Document: c.newDocument(res.Code)}
+	if res.Error != nil {
+		if result.Document.Name == "" {
+			result.Document.Name = res.Code
+		}
+		return result
+	}
+
+	// Schema drift is reported alongside whatever the parsers could extract
+	result.Validation = c.ValidateDocument(ctx, res.Data)
+
+	var err error
+	switch res.Code {
+	case "FRD":
+		result.Definitions, err = c.ParseDefinitions(res.Data)
+	case "KSI":
+		// KSI also carries base requirements in FRR.KSI, like other documents
+		result.Indicators, err = c.ParseIndicators(res.Data)
+		if err == nil && hasSection(res.Data, "FRR") {
+			result.Requirements, err = c.ParseRequirements(res.Data, res.Code)
+		}
+	default:
+		result.Requirements, err = c.ParseRequirements(res.Data, res.Code)
+	}
+	if err != nil {
+		result.ParseError = err
+	}
+
+	info, err := c.ParseDocumentInfo(res.Data)
+	if err != nil {
+		if result.ParseError == nil {
+			result.ParseError = fmt.Errorf("parsing info: %w", err)
+		}
+	} else {
+		result.Info = info
+	}
+	EnrichDocument(&result.Document, result.Info)
+	if result.Document.Name == "" {
+		result.Document.Name = res.Code
+	}
+
+	return result
+}
+
+// hasSection returns true if the document has the named top-level section
+func hasSection(data []byte, name string) bool {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return false
+	}
+	_, ok := doc[name]
+	return ok
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// objectKeys returns the keys of a JSON object in the order they appear.
+// Go maps lose that order, and FRMR documents list themes, categories and
+// program versions in a meaningful one.
+func objectKeys(data []byte) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, fmt.Errorf("expected JSON object, got %v", tok)
+	}
+
+	var keys []string
+	seen := make(map[string]bool)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		// A repeated key keeps its first position, as the last value wins
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// decodeOrdered unmarshals a JSON object into a map and also returns its keys
+// in document order
+func decodeOrdered[T any](data []byte) ([]string, map[string]T, error) {
+	var values map[string]T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, nil, err
+	}
+	if values == nil {
+		return nil, nil, nil
+	}
+	keys, err := objectKeys(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return keys, values, nil
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"slices"
+	"testing"
+)
+
+// orderedDocument lists themes, categories and versions out of alphabetical
+// order, with enough keys that map iteration would almost never match it
+const orderedDocument = `{
+	"info": {"name": "Ordered", "short_name": "ORD", "effective": {
+		"rev5": {"is": "optional"}, "20x": {"is": "required"}, "legacy": {"is": "retired"}
+	}},
+	"FRR": {"ORD": {
+		"zeta": {"requirements": [{"id": "Z-1"}]},
+		"base": {"requirements": [{"id": "B-1"}, {"id": "B-2"}]},
+		"mid": {"requirements": [{"id": "M-1"}]},
+		"alpha": {"requirements": [{"id": "A-1"}]},
+		"tr": {"requirements": [{"id": "T-1"}]},
+		"exc": {"requirements": [{"id": "E-1"}]},
+		"kappa": {"requirements": [{"id": "K-1"}]},
+		"omega": {"requirements": [{"id": "O-1"}]},
+		"beta": {"requirements": [{"id": "BE-1"}]}
+	}},
+	"KSI": {
+		"SVC": {"indicators": [{"id": "KSI-SVC-01"}]},
+		"AFR": {"indicators": [{"id": "KSI-AFR-01"}, {"id": "KSI-AFR-02"}]},
+		"TPR": {"indicators": [{"id": "KSI-TPR-01"}]},
+		"CED": {"indicators": [{"id": "KSI-CED-01"}]},
+		"IAM": {"indicators": [{"id": "KSI-IAM-01"}]},
+		"MLA": {"indicators": [{"id": "KSI-MLA-01"}]},
+		"CNA": {"indicators": [{"id": "KSI-CNA-01"}]},
+		"RPL": {"indicators": [{"id": "KSI-RPL-01"}]},
+		"INR": {"indicators": [{"id": "KSI-INR-01"}]}
+	}
+}`
+
+func TestSourceOrderIsPreserved(t *testing.T) {
+	client := NewClient(WithCache(nil))
+	data := []byte(orderedDocument)
+
+	// Repeat to catch map iteration order leaking through
+	for range 5 {
+		reqs, err := client.ParseRequirements(data, "ORD")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, r := range reqs {
+			ids = append(ids, r.ID)
+		}
+		want := []string{"Z-1", "B-1", "B-2", "M-1", "A-1", "T-1", "E-1", "K-1", "O-1", "BE-1"}
+		if !slices.Equal(ids, want) {
+			t.Fatalf("Expected requirements in category order %v, got %v", want, ids)
+		}
+
+		indicators, err := client.ParseIndicators(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = ids[:0]
+		for _, ind := range indicators {
+			ids = append(ids, ind.ID)
+		}
+		want = []string{"KSI-SVC-01", "KSI-AFR-01", "KSI-AFR-02", "KSI-TPR-01", "KSI-CED-01", "KSI-IAM-01", "KSI-MLA-01", "KSI-CNA-01", "KSI-RPL-01", "KSI-INR-01"}
+		if !slices.Equal(ids, want) {
+			t.Fatalf("Expected indicators in theme order %v, got %v", want, ids)
+		}
+
+		info, err := client.ParseDocumentInfo(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc := client.newDocument("ORD")
+		EnrichDocument(&doc, info)
+		var versions []string
+		for _, eff := range doc.EffectiveInfo {
+			versions = append(versions, eff.Version+"="+eff.Is)
+		}
+		if want := []string{"rev5=optional", "20x=required", "legacy=retired"}; !slices.Equal(versions, want) {
+			t.Fatalf("Expected effective versions %v, got %v", want, versions)
+		}
+	}
+}
+
+func TestObjectKeys(t *testing.T) {
+	keys, err := objectKeys([]byte(`{"b": {"nested": [1, {"x": 2}]}, "a": null, "b": 3}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(keys, []string{"b", "a"}) {
+		t.Errorf("Expected [b a], got %v", keys)
+	}
+	if _, err := objectKeys([]byte(`[1, 2]`)); err == nil {
+		t.Error("Expected error for a JSON array")
+	}
+
+	keys, values, err := decodeOrdered[int]([]byte(`null`))
+	if err != nil || keys != nil || values != nil {
+		t.Errorf("Expected null to decode to nothing, got %v %v %v", keys, values, err)
+	}
+}
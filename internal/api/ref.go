@@ -0,0 +1,109 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/snapshot"
+)
+
+// Repository is the upstream GitHub repository documents are published in
+const Repository = "FedRAMP/docs"
+
+// DefaultRef is the branch loaded when no ref is pinned
+const DefaultRef = "main"
+
+var (
+	refPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+	shaPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+)
+
+// RefURL returns the raw.githubusercontent.com base URL for a branch, tag or commit SHA
+func RefURL(ref string) string {
+	return githubRawPrefix + Repository + "/" + ref
+}
+
+// ValidateRef checks that ref is a plausible git branch, tag or commit name
+func ValidateRef(ref string) error {
+	if !refPattern.MatchString(ref) || strings.Contains(ref, "..") || strings.HasSuffix(ref, "/") {
+		return fmt.Errorf("invalid ref %q", ref)
+	}
+	return nil
+}
+
+// IsCommitSHA returns true if ref is a full commit SHA. Content at a commit
+// never changes, so cache entries for it do not need revalidation.
+func IsCommitSHA(ref string) bool {
+	return shaPattern.MatchString(ref)
+}
+
+// WithRef loads documents from a branch, tag or commit of FedRAMP/docs
+// instead of main
+func WithRef(ref string) ClientOption {
+	return func(c *Client) {
+		c.source = NewHTTPSource(RefURL(ref))
+	}
+}
+
+// parseGitHubRaw splits a raw.githubusercontent.com base URL into its
+// owner/name repository and ref
+func parseGitHubRaw(baseURL string) (repo, ref string, ok bool) {
+	rest, ok := strings.CutPrefix(baseURL, githubRawPrefix)
+	if !ok {
+		return "", "", false
+	}
+	parts := strings.SplitN(rest, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", false
+	}
+	return parts[0] + "/" + parts[1], parts[2], true
+}
+
+// Ref returns the git ref documents are loaded from, or "" for sources that
+// are not a GitHub repository
+func (s *HTTPSource) Ref() string {
+	_, ref, _ := parseGitHubRaw(s.baseURL)
+	return ref
+}
+
+// Ref returns the git ref documents are loaded from, or "" when the source is
+// not a GitHub repository
+func (c *Client) Ref() string {
+	if src, ok := c.source.(*HTTPSource); ok {
+		return src.Ref()
+	}
+	return ""
+}
+
+// SourceLabel identifies where documents come from, e.g. "FedRAMP/docs@v1.2.0"
+// or the path of a local directory. It is shown in the header and in exports
+// so data can be cited by version.
+func (c *Client) SourceLabel() string {
+	if src, ok := c.source.(*HTTPSource); ok {
+		if repo, ref, ok := parseGitHubRaw(src.baseURL); ok {
+			return repo + "@" + ref
+		}
+	}
+	return strings.TrimRight(c.source.Location(""), "/!")
+}
+
+// pinnedCommit returns true if documents are loaded from a fixed commit
+func (c *Client) pinnedCommit() bool {
+	return IsCommitSHA(c.Ref())
+}
+
+// snapshotUsable returns true if the embedded snapshot may stand in for the
+// configured source. A pinned tag or commit must not silently show data from
+// a different version.
+func (c *Client) snapshotUsable() bool {
+	ref := c.Ref()
+	if ref == "" || ref == DefaultRef {
+		return true
+	}
+	m := snapshot.Load()
+	if len(ref) >= 7 && strings.HasPrefix(m.Ref, ref) {
+		return true
+	}
+	return strings.HasPrefix(m.Release, ref+"@")
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/cache"
+)
+
+func TestValidateRef(t *testing.T) {
+	for _, ref := range []string{"main", "v1.2.0", "release/2025-06", "0123456789abcdef0123456789abcdef01234567"} {
+		if err := ValidateRef(ref); err != nil {
+			t.Errorf("ValidateRef(%q): %v", ref, err)
+		}
+	}
+	for _, ref := range []string{"", "-main", "../etc", "a b", "main/", "v1?x=1"} {
+		if err := ValidateRef(ref); err == nil {
+			t.Errorf("Expected ValidateRef(%q) to fail", ref)
+		}
+	}
+}
+
+func TestWithRef(t *testing.T) {
+	client := NewClient(WithRef("v1.2.0"), WithCache(nil))
+	if got := client.Ref(); got != "v1.2.0" {
+		t.Errorf("Expected ref v1.2.0, got %q", got)
+	}
+	if got := client.SourceLabel(); got != "FedRAMP/docs@v1.2.0" {
+		t.Errorf("Unexpected source label %q", got)
+	}
+	if got := NewClient(WithCache(nil)).SourceLabel(); got != "FedRAMP/docs@main" {
+		t.Errorf("Expected default source label, got %q", got)
+	}
+
+	src := client.source.(*HTTPSource)
+	if src.ContentsURL != "https://api.github.com/repos/FedRAMP/docs/contents?ref=v1.2.0" {
+		t.Errorf("Expected listing at the pinned ref, got %s", src.ContentsURL)
+	}
+
+	// Cache entries for different refs never collide
+	other := NewClient(WithRef("v1.3.0"), WithCache(nil))
+	filename := DocumentFiles["VDR"].Filename
+	if client.source.Location(filename) == other.source.Location(filename) {
+		t.Error("Expected cache keys to differ between refs")
+	}
+
+	// Embedded data from another version must not stand in for a pinned ref
+	if client.snapshotUsable() {
+		t.Error("Expected snapshot fallback to be disabled for a pinned tag")
+	}
+
+	dir, err := NewDirSource(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := NewClient(WithSource(dir), WithCache(nil)).Ref(); got != "" {
+		t.Errorf("Expected no ref for a directory source, got %q", got)
+	}
+}
+
+func TestPinnedCommitSkipsRevalidation(t *testing.T) {
+	const sha = "0123456789abcdef0123456789abcdef01234567"
+	c := &cache.Cache{Dir: t.TempDir(), TTL: time.Hour}
+	client := NewClient(WithRef(sha), WithCache(c), WithSnapshot(false), WithRetry(1, 0))
+
+	filename := DocumentFiles["VDR"].Filename
+	key := client.source.Location(filename)
+	meta := cache.Metadata{FetchedAt: time.Now().Add(-30 * 24 * time.Hour), SourceURL: key, Ref: sha}
+	if err := c.Put(key, fixtureDocument("VDR"), meta); err != nil {
+		t.Fatal(err)
+	}
+
+	res := client.fetch(t.Context(), filename)
+	if res.Error != nil || res.Origin != OriginCache {
+		t.Errorf("Expected cached copy of a pinned commit to be served without a request, got %v %v", res.Origin, res.Error)
+	}
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Default retry policy for fetching documents
+const (
+	DefaultMaxAttempts = 4
+	DefaultBaseDelay   = 500 * time.Millisecond
+	DefaultMaxDelay    = 10 * time.Second
+	// MaxRetryAfter caps how long a rate limit response may delay a fetch;
+	// longer waits fail immediately so stale or snapshot data can be used
+	MaxRetryAfter = 2 * time.Minute
+)
+
+// StatusError is returned for unexpected HTTP responses
+type StatusError struct {
+	StatusCode int
+	Status     string
+	RetryAfter time.Duration // Server-requested delay, from Retry-After or X-RateLimit-Reset
+	RateLimit  bool          // The response indicates a rate limit (429, or 403 with no remaining quota)
+}
+
+func (e *StatusError) Error() string {
+	if e.RateLimit {
+		return fmt.Sprintf("HTTP %d: %s (rate limited, retry after %s)", e.StatusCode, e.Status, e.RetryAfter)
+	}
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Status)
+}
+
+// newStatusError builds a StatusError, recognising GitHub rate limit responses
+func newStatusError(resp *http.Response) *StatusError {
+	e := &StatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		e.RateLimit = true
+	case resp.StatusCode == http.StatusForbidden &&
+		(resp.Header.Get("X-RateLimit-Remaining") == "0" || resp.Header.Get("Retry-After") != ""):
+		e.RateLimit = true
+	}
+
+	if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		e.RetryAfter = d
+	} else if e.RateLimit {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			e.RetryAfter = max(time.Until(time.Unix(reset, 0)), 0)
+		}
+	}
+
+	return e
+}
+
+// parseRetryAfter parses a Retry-After header in delay-seconds or HTTP-date form
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// retryable returns true if a fetch error is worth retrying
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrNotFound) {
+		return false
+	}
+	var se *StatusError
+	if errors.As(err, &se) {
+		return se.RateLimit || se.StatusCode >= 500
+	}
+	// Network errors
+	return true
+}
+
+// backoff returns the delay before retry number attempt (starting at 0),
+// using exponential backoff with jitter in [d/2, d]. A zero base delay
+// retries immediately.
+func (c *Client) backoff(attempt int) time.Duration {
+	if c.baseDelay <= 0 {
+		return 0
+	}
+	d := c.baseDelay << attempt
+	// A large attempt can shift the delay past the sign bit, or out entirely
+	if d > c.maxDelay || d <= 0 {
+		d = c.maxDelay
+	}
+	half := d / 2
+	return half + rand.N(half+1)
+}
+
+// retryDelay returns how long to wait before retrying err, or false if the
+// fetch should not be retried
+func (c *Client) retryDelay(err error, attempt int) (time.Duration, bool) {
+	if attempt+1 >= c.maxAttempts || !retryable(err) {
+		return 0, false
+	}
+	var se *StatusError
+	if errors.As(err, &se) && se.RetryAfter > 0 {
+		if se.RetryAfter > MaxRetryAfter {
+			return 0, false
+		}
+		return se.RetryAfter, true
+	}
+	return c.backoff(attempt), true
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
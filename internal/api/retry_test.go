@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// recordSleeps replaces the client's sleep with one that records delays
+func recordSleeps(c *Client) *[]time.Duration {
+	var delays []time.Duration
+	c.sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return ctx.Err()
+	}
+	return &delays
+}
+
+func TestRetryTransientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(fixtureDocument("VDR"))
+	}))
+	defer srv.Close()
+
+	client := NewClient(WithSource(NewHTTPSource(srv.URL)), WithCache(nil), WithSnapshot(false), WithRetry(4, 100*time.Millisecond))
+	delays := recordSleeps(client)
+
+	res := client.fetch(context.Background(), DocumentFiles["VDR"].Filename)
+	if res.Error != nil {
+		t.Fatalf("Expected success after retries, got %v", res.Error)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls.Load())
+	}
+	if len(*delays) != 2 {
+		t.Fatalf("Expected 2 backoff delays, got %v", *delays)
+	}
+	// Jittered exponential backoff: [50ms,100ms] then [100ms,200ms]
+	for i, d := range *delays {
+		upper := 100 * time.Millisecond << i
+		if d < upper/2 || d > upper {
+			t.Errorf("Delay %d = %s, want within [%s, %s]", i, d, upper/2, upper)
+		}
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "7")
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write(fixtureDocument("VDR"))
+	}))
+	defer srv.Close()
+
+	client := NewClient(WithSource(NewHTTPSource(srv.URL)), WithCache(nil), WithSnapshot(false))
+	delays := recordSleeps(client)
+
+	if res := client.fetch(context.Background(), DocumentFiles["VDR"].Filename); res.Error != nil {
+		t.Fatalf("Expected success after rate limit, got %v", res.Error)
+	}
+	if len(*delays) != 1 || (*delays)[0] != 7*time.Second {
+		t.Errorf("Expected a single 7s delay, got %v", *delays)
+	}
+}
+
+func TestGitHubRateLimit(t *testing.T) {
+	reset := time.Now().Add(30 * time.Second).Unix()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+		http.Error(w, "rate limit exceeded", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	_, err := NewHTTPSource(srv.URL).Fetch(context.Background(), "FRMR.VDR.x.json")
+	var se *StatusError
+	if !errors.As(err, &se) {
+		t.Fatalf("Expected StatusError, got %v", err)
+	}
+	if !se.RateLimit || se.RetryAfter <= 0 || se.RetryAfter > 30*time.Second {
+		t.Errorf("Expected rate limit with reset delay, got %+v", se)
+	}
+
+	// A plain 403 is not retried
+	plain := &StatusError{StatusCode: http.StatusForbidden}
+	if retryable(plain) {
+		t.Error("Expected plain 403 not to be retryable")
+	}
+
+	// Rate limits that would block for too long fail immediately
+	client := NewClient(WithCache(nil))
+	if _, ok := client.retryDelay(&StatusError{StatusCode: 429, RateLimit: true, RetryAfter: time.Hour}, 0); ok {
+		t.Error("Expected long Retry-After not to be retried")
+	}
+}
+
+func TestFetchCancellation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	client := NewClient(WithSource(NewHTTPSource(srv.URL)), WithCache(nil))
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	results := client.FetchDocumentsContext(ctx, []string{"VDR", "UCM"})
+	if time.Since(start) > 5*time.Second {
+		t.Error("Expected cancellation to stop in-flight fetches")
+	}
+	for _, res := range results {
+		if !errors.Is(res.Error, context.Canceled) {
+			t.Errorf("%s: expected context.Canceled, got %v", res.Code, res.Error)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{"Wed, 01 Jan 2025 12:00:30 GMT", 30 * time.Second, true},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %s, %v; want %s, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestBackoffBounds(t *testing.T) {
+	client := NewClient(WithCache(nil), WithRetry(4, 0))
+	if d := client.backoff(3); d != 0 {
+		t.Errorf("Expected a zero base delay to retry immediately, got %s", d)
+	}
+
+	client = NewClient(WithCache(nil), WithRetry(4, time.Second))
+	for _, attempt := range []int{10, 40, 63, 64, 100} {
+		if d := client.backoff(attempt); d < DefaultMaxDelay/2 || d > DefaultMaxDelay {
+			t.Errorf("backoff(%d) = %s, want within [%s, %s]", attempt, d, DefaultMaxDelay/2, DefaultMaxDelay)
+		}
+	}
+}
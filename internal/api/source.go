@@ -0,0 +1,284 @@
+package api
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Source provides raw FRMR document files by filename
+type Source interface {
+	// Fetch returns the contents of the named document file
+	Fetch(ctx context.Context, filename string) ([]byte, error)
+	// Location returns a stable identifier for the file, used as the cache key
+	Location(filename string) string
+}
+
+// Response is the result of a conditional fetch
+type Response struct {
+	Data         []byte
+	ETag         string
+	LastModified string
+	NotModified  bool // The file is unchanged since the validators were issued; Data is nil
+}
+
+// ConditionalSource is implemented by sources that can revalidate a
+// previously fetched file (e.g. via If-None-Match/If-Modified-Since) instead
+// of transferring it again
+type ConditionalSource interface {
+	Source
+	FetchConditional(ctx context.Context, filename, etag, lastModified string) (*Response, error)
+}
+
+// ErrNotFound is returned by a Source when a file does not exist
+var ErrNotFound = errors.New("document not found")
+
+// ParseSource builds a Source from a --source value. An empty value or an
+// http(s) URL selects the HTTP backend, a path ending in .tar.gz or .tgz selects
+// the archive backend, and any other path must be a directory of FRMR files.
+func ParseSource(spec string) (Source, error) {
+	switch {
+	case spec == "":
+		return NewHTTPSource(BaseURL), nil
+	case strings.HasPrefix(spec, "http://"), strings.HasPrefix(spec, "https://"):
+		return NewHTTPSource(spec), nil
+	case strings.HasSuffix(spec, ".tar.gz"), strings.HasSuffix(spec, ".tgz"):
+		return NewArchiveSource(spec)
+	}
+
+	info, err := os.Stat(spec)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("source %s is neither a directory nor a .tar.gz archive", spec)
+	}
+	return NewDirSource(spec)
+}
+
+// HTTPSource fetches documents from a base URL such as raw.githubusercontent.com
+type HTTPSource struct {
+	// ContentsURL is the GitHub contents API URL used to list documents. It is
+	// derived from raw.githubusercontent.com base URLs; when empty, documents
+	// are listed from the manifest file instead.
+	ContentsURL string
+
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewHTTPSource creates a source that fetches documents relative to baseURL
+func NewHTTPSource(baseURL string) *HTTPSource {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return &HTTPSource{
+		ContentsURL: contentsURLFor(baseURL),
+		baseURL:     baseURL,
+		httpClient:  &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// Location returns the URL of the file
+func (s *HTTPSource) Location(filename string) string {
+	return s.baseURL + "/" + filename
+}
+
+// Fetch downloads the file
+func (s *HTTPSource) Fetch(ctx context.Context, filename string) ([]byte, error) {
+	resp, err := s.FetchConditional(ctx, filename, "", "")
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// FetchConditional downloads the file unless the server reports it unchanged
+// since the response identified by etag/lastModified
+func (s *HTTPSource) FetchConditional(ctx context.Context, filename, etag, lastModified string) (*Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.Location(filename), nil)
+	if err != nil {
+		return nil, err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	result := &Response{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		result.Data, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	case http.StatusNotModified:
+		result.NotModified = true
+		return result, nil
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%s: %w", filename, ErrNotFound)
+	default:
+		return nil, newStatusError(resp)
+	}
+}
+
+// get downloads an arbitrary URL, such as a schema a document declares
+func (s *HTTPSource) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(resp.Body)
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%s: %w", url, ErrNotFound)
+	default:
+		return nil, newStatusError(resp)
+	}
+}
+
+// DirSource reads documents from a local directory, e.g. a checkout of FedRAMP/docs
+type DirSource struct {
+	dir string
+}
+
+// NewDirSource creates a source backed by a local directory
+func NewDirSource(dir string) (*DirSource, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &DirSource{dir: abs}, nil
+}
+
+// Location returns the absolute path of the file
+func (s *DirSource) Location(filename string) string {
+	return filepath.Join(s.dir, filename)
+}
+
+// Fetch reads the file from disk
+func (s *DirSource) Fetch(ctx context.Context, filename string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(s.Location(filepath.Base(filename)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s: %w", filename, ErrNotFound)
+	}
+	return data, err
+}
+
+// ArchiveSource reads documents from a .tar.gz archive such as a GitHub
+// release tarball. Files are matched by base name at any depth, since GitHub
+// tarballs nest everything under a top-level directory.
+type ArchiveSource struct {
+	path string
+
+	once  sync.Once
+	files map[string][]byte
+	err   error
+}
+
+// NewArchiveSource creates a source backed by a .tar.gz archive
+func NewArchiveSource(archivePath string) (*ArchiveSource, error) {
+	abs, err := filepath.Abs(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(abs); err != nil {
+		return nil, err
+	}
+	return &ArchiveSource{path: abs}, nil
+}
+
+// Location returns the archive path with the file name appended
+func (s *ArchiveSource) Location(filename string) string {
+	return s.path + "!" + filename
+}
+
+// Fetch returns the named file from the archive
+func (s *ArchiveSource) Fetch(ctx context.Context, filename string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.once.Do(s.load)
+	if s.err != nil {
+		return nil, s.err
+	}
+	data, ok := s.files[filename]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", filename, ErrNotFound)
+	}
+	return data, nil
+}
+
+// load reads every FRMR JSON file in the archive into memory once
+func (s *ArchiveSource) load() {
+	f, err := os.Open(s.path)
+	if err != nil {
+		s.err = err
+		return
+	}
+	defer func() { _ = f.Close() }()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		s.err = fmt.Errorf("reading %s: %w", s.path, err)
+		return
+	}
+	defer func() { _ = gz.Close() }()
+
+	s.files = make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			s.err = fmt.Errorf("reading %s: %w", s.path, err)
+			return
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Base(hdr.Name)
+		if _, ok := ParseDocumentFilename(name); !ok {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			s.err = fmt.Errorf("reading %s from %s: %w", hdr.Name, s.path, err)
+			return
+		}
+		s.files[name] = data
+	}
+}
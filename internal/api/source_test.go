@@ -0,0 +1,299 @@
+package api
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/cache"
+)
+
+// fixtureDocument returns a minimal FRMR document for a code
+func fixtureDocument(code string) []byte {
+	return []byte(`{"info":{"name":"` + code + ` fixture","short_name":"` + code + `"}}`)
+}
+
+func writeFixtureDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for code, meta := range DocumentFiles {
+		if err := os.WriteFile(filepath.Join(dir, meta.Filename), fixtureDocument(code), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func writeFixtureArchive(t *testing.T) string {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for code, meta := range DocumentFiles {
+		data := fixtureDocument(code)
+		hdr := &tar.Header{
+			Name:     "FedRAMP-docs-abc123/" + meta.Filename,
+			Mode:     0644,
+			Size:     int64(len(data)),
+			Typeflag: tar.TypeReg,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "docs.tar.gz")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func newFixtureServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.FileServer(http.Dir(writeFixtureDir(t))))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestParseSource(t *testing.T) {
+	dir := writeFixtureDir(t)
+	archive := writeFixtureArchive(t)
+
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"", "*api.HTTPSource"},
+		{"https://example.com/docs", "*api.HTTPSource"},
+		{dir, "*api.DirSource"},
+		{archive, "*api.ArchiveSource"},
+	}
+	for _, tt := range tests {
+		src, err := ParseSource(tt.spec)
+		if err != nil {
+			t.Fatalf("ParseSource(%q): %v", tt.spec, err)
+		}
+		if got := fmt.Sprintf("%T", src); got != tt.want {
+			t.Errorf("ParseSource(%q) = %s, want %s", tt.spec, got, tt.want)
+		}
+	}
+
+	if _, err := ParseSource(filepath.Join(dir, "missing")); err == nil {
+		t.Error("Expected error for a missing path")
+	}
+}
+
+func TestFetchAllDocumentsFromSources(t *testing.T) {
+	srv := newFixtureServer(t)
+	dirSource, _ := NewDirSource(writeFixtureDir(t))
+	archiveSource, _ := NewArchiveSource(writeFixtureArchive(t))
+
+	sources := map[string]Source{
+		"http":    NewHTTPSource(srv.URL),
+		"dir":     dirSource,
+		"archive": archiveSource,
+	}
+
+	for name, src := range sources {
+		t.Run(name, func(t *testing.T) {
+			c := &cache.Cache{Dir: t.TempDir(), TTL: time.Hour}
+			client := NewClient(WithSource(src), WithCache(c))
+
+			docs, err := client.FetchAllDocuments()
+			if err != nil {
+				t.Fatalf("FetchAllDocuments: %v", err)
+			}
+			if len(docs) != len(DocumentFiles) {
+				t.Fatalf("Expected %d documents, got %d", len(DocumentFiles), len(docs))
+			}
+			if !bytes.Equal(docs["VDR"], fixtureDocument("VDR")) {
+				t.Errorf("Unexpected VDR contents: %s", docs["VDR"])
+			}
+
+			// Documents are cached under the source location
+			key := src.Location(DocumentFiles["VDR"].Filename)
+			if _, ok := c.Get(key); !ok {
+				t.Errorf("Expected %s to be cached", key)
+			}
+		})
+	}
+}
+
+func TestSourceNotFound(t *testing.T) {
+	srv := newFixtureServer(t)
+	dirSource, _ := NewDirSource(t.TempDir())
+	archiveSource, _ := NewArchiveSource(writeFixtureArchive(t))
+
+	for _, src := range []Source{NewHTTPSource(srv.URL), dirSource, archiveSource} {
+		if _, err := src.Fetch(context.Background(), "FRMR.XYZ.missing.json"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s: expected ErrNotFound, got %v", fmt.Sprintf("%T", src), err)
+		}
+	}
+}
+
+func TestConditionalRevalidation(t *testing.T) {
+	const etag = `"v1"`
+	var full, notModified int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full++
+		_, _ = w.Write(fixtureDocument("VDR"))
+	}))
+	defer srv.Close()
+
+	c := &cache.Cache{Dir: t.TempDir(), TTL: time.Hour}
+	src := NewHTTPSource(srv.URL)
+	filename := DocumentFiles["VDR"].Filename
+
+	// First fetch downloads and records the validators
+	res := NewClient(WithSource(src), WithCache(c)).fetch(context.Background(), filename)
+	if res.Error != nil || res.Origin != OriginSource {
+		t.Fatalf("Expected fetch from source, got origin=%v err=%v", res.Origin, res.Error)
+	}
+	entry, ok := c.Lookup(src.Location(filename))
+	if !ok || entry.ETag != etag || entry.SourceURL != src.Location(filename) {
+		t.Fatalf("Expected cached metadata with ETag %s, got %+v", etag, entry)
+	}
+
+	// A fresh entry is served without contacting the server
+	res = NewClient(WithSource(src), WithCache(c)).fetch(context.Background(), filename)
+	if res.Origin != OriginCache {
+		t.Errorf("Expected fresh cache hit, got %v", res.Origin)
+	}
+
+	// --refresh revalidates instead of downloading again
+	before := entry.FetchedAt
+	res = NewClient(WithSource(src), WithCache(c), WithRefresh(true)).fetch(context.Background(), filename)
+	if res.Error != nil || res.Origin != OriginRevalidated {
+		t.Fatalf("Expected revalidated copy, got origin=%v err=%v", res.Origin, res.Error)
+	}
+	if !bytes.Equal(res.Data, fixtureDocument("VDR")) {
+		t.Errorf("Expected cached data after 304, got %s", res.Data)
+	}
+	if full != 1 || notModified != 1 {
+		t.Errorf("Expected 1 full download and 1 revalidation, got %d and %d", full, notModified)
+	}
+	entry, _ = c.Lookup(src.Location(filename))
+	if !entry.FetchedAt.After(before) {
+		t.Error("Expected 304 to renew the entry's fetch time")
+	}
+}
+
+func TestStaleCacheFallback(t *testing.T) {
+	up := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !up {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(fixtureDocument("VDR"))
+	}))
+	defer srv.Close()
+
+	c := &cache.Cache{Dir: t.TempDir(), TTL: time.Hour}
+	src := NewHTTPSource(srv.URL)
+	client := NewClient(WithSource(src), WithCache(c), WithSnapshot(false), WithRetry(1, 0))
+	filename := DocumentFiles["VDR"].Filename
+
+	if res := client.fetch(context.Background(), filename); res.Error != nil {
+		t.Fatal(res.Error)
+	}
+
+	// Expire the entry and take the source down
+	fetchedAt := time.Now().Add(-48 * time.Hour)
+	if err := c.Renew(src.Location(filename), cache.Metadata{FetchedAt: fetchedAt}); err != nil {
+		t.Fatal(err)
+	}
+	up = false
+
+	res := client.fetch(context.Background(), filename)
+	if res.Error != nil {
+		t.Fatalf("Expected stale data instead of an error, got %v", res.Error)
+	}
+	if !res.Stale() || res.RefreshError == nil {
+		t.Errorf("Expected stale result with refresh error, got %+v", res)
+	}
+	if !res.FetchedAt.Equal(fetchedAt) {
+		t.Errorf("Expected FetchedAt %v, got %v", fetchedAt, res.FetchedAt)
+	}
+
+	// Without any cached copy the failure is reported
+	if res := client.fetch(context.Background(), DocumentFiles["UCM"].Filename); res.Error == nil {
+		t.Error("Expected error without a cached copy")
+	}
+}
+
+func TestLoadReportsParseErrors(t *testing.T) {
+	dir := writeFixtureDir(t)
+	src, _ := NewDirSource(dir)
+	client := NewClient(WithSource(src), WithCache(nil), WithSnapshot(false), WithRetry(1, 0))
+
+	results := client.Load([]string{"VDR", "XYZ"})
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+
+	// The fixture has an info section but no FRR.VDR requirements
+	vdr := results[0]
+	if vdr.Error != nil || vdr.ParseError == nil || !vdr.Failed() {
+		t.Errorf("Expected VDR to fail parsing, got fetch=%v parse=%v", vdr.Error, vdr.ParseError)
+	}
+	if vdr.Info == nil || vdr.Info.Name != "VDR fixture" {
+		t.Errorf("Expected info to still be parsed, got %+v", vdr.Info)
+	}
+
+	if results[1].Error == nil {
+		t.Error("Expected unknown document to fail fetching")
+	}
+}
+
+func TestLoadKSIRequirements(t *testing.T) {
+	dir := writeFixtureDir(t)
+	ksi := `{
+		"info": {"name": "Key Security Indicators", "short_name": "KSI"},
+		"FRR": {"KSI": {"base": {"id": "base", "requirements": [
+			{"id": "FRR-KSI-01", "statement": "Providers MUST...", "primary_key_word": "MUST", "affects": ["Providers"]}
+		]}}},
+		"KSI": {"CED": {"name": "Cybersecurity Education", "indicators": [{"id": "KSI-CED-01", "name": "Training"}]}}
+	}`
+	if err := os.WriteFile(filepath.Join(dir, DocumentFiles["KSI"].Filename), []byte(ksi), 0644); err != nil {
+		t.Fatal(err)
+	}
+	src, _ := NewDirSource(dir)
+	client := NewClient(WithSource(src), WithCache(nil))
+
+	res := client.Load([]string{"KSI"})[0]
+	if res.Failed() {
+		t.Fatalf("Loading KSI failed: %v %v", res.Error, res.ParseError)
+	}
+	if len(res.Indicators) != 1 {
+		t.Errorf("Expected 1 indicator, got %d", len(res.Indicators))
+	}
+	if len(res.Requirements) != 1 || res.Requirements[0].DocumentCode != "KSI" || !res.Requirements[0].IsMust() {
+		t.Errorf("Expected the FRR.KSI base requirement, got %+v", res.Requirements)
+	}
+}
@@ -4,11 +4,34 @@ import "encoding/json"
 
 // DocumentInfo represents the common info structure in all FedRAMP documents
 type DocumentInfo struct {
-	Name        string                    `json:"name"`
-	ShortName   string                    `json:"short_name"`
-	Effective   map[string]EffectiveInfo  `json:"effective"`
-	Releases    []Release                 `json:"releases"`
-	FrontMatter FrontMatter               `json:"front_matter"`
+	Name        string            `json:"name"`
+	ShortName   string            `json:"short_name"`
+	Effective   EffectiveVersions `json:"effective"`
+	Releases    []Release         `json:"releases"`
+	FrontMatter FrontMatter       `json:"front_matter"`
+}
+
+// EffectiveVersions is the effective section, keyed by program version (e.g.
+// "20x", "rev5") and kept in document order
+type EffectiveVersions []EffectiveVersion
+
+// EffectiveVersion is the applicability of a document to one program version
+type EffectiveVersion struct {
+	Version string
+	EffectiveInfo
+}
+
+// UnmarshalJSON decodes the effective object, preserving key order
+func (e *EffectiveVersions) UnmarshalJSON(data []byte) error {
+	versions, infos, err := decodeOrdered[EffectiveInfo](data)
+	if err != nil {
+		return err
+	}
+	*e = make(EffectiveVersions, 0, len(versions))
+	for _, version := range versions {
+		*e = append(*e, EffectiveVersion{Version: version, EffectiveInfo: infos[version]})
+	}
+	return nil
 }
 
 // EffectiveInfo represents version-specific applicability
@@ -68,24 +91,42 @@ type RequirementJSON struct {
 	PrimaryKeyWord       string             `json:"primary_key_word"`
 	Note                 string             `json:"note"`
 	FollowingInformation FollowingInfoField `json:"-"` // Custom unmarshaling
+	FollowingText        []string           `json:"-"` // String items of following_information
 	RawFollowingInfo     json.RawMessage    `json:"following_information"`
 }
 
 // FollowingInfoField handles following_information which can be string or []RequirementJSON
 type FollowingInfoField []RequirementJSON
 
-// UnmarshalFollowingInfo processes the raw following_information field after initial unmarshal
+// UnmarshalFollowingInfo processes the raw following_information field after
+// initial unmarshal. It may be a string, or a list mixing strings and nested
+// requirements; strings are kept in FollowingText and requirements in
+// FollowingInformation, each in document order.
 func (r *RequirementJSON) UnmarshalFollowingInfo() {
-	if r.RawFollowingInfo == nil || len(r.RawFollowingInfo) == 0 {
+	if len(r.RawFollowingInfo) == 0 {
 		return
 	}
-	// Try as array of requirements first
-	var reqs []RequirementJSON
-	if err := json.Unmarshal(r.RawFollowingInfo, &reqs); err == nil {
-		r.FollowingInformation = reqs
+	var text string
+	if err := json.Unmarshal(r.RawFollowingInfo, &text); err == nil {
+		if text != "" {
+			r.FollowingText = []string{text}
+		}
 		return
 	}
-	// Otherwise it's a string or other type, ignore
+	var items []json.RawMessage
+	if err := json.Unmarshal(r.RawFollowingInfo, &items); err != nil {
+		return
+	}
+	for _, item := range items {
+		if err := json.Unmarshal(item, &text); err == nil {
+			r.FollowingText = append(r.FollowingText, text)
+			continue
+		}
+		var req RequirementJSON
+		if err := json.Unmarshal(item, &req); err == nil {
+			r.FollowingInformation = append(r.FollowingInformation, req)
+		}
+	}
 }
 
 // ImpactJSON represents impact levels
@@ -117,15 +158,15 @@ type DefinitionJSON struct {
 
 // IndicatorJSON represents a KSI indicator
 type IndicatorJSON struct {
-	ID           string       `json:"id"`
-	Name         string       `json:"name"`
-	Statement    string       `json:"statement"`
-	Impact       ImpactJSON   `json:"impact"`
+	ID           string        `json:"id"`
+	Name         string        `json:"name"`
+	Statement    string        `json:"statement"`
+	Impact       ImpactJSON    `json:"impact"`
 	Controls     []ControlJSON `json:"controls"`
-	Reference    string       `json:"reference"`
-	ReferenceURL string       `json:"reference_url"`
-	Note         string       `json:"note"`
-	Retired      bool         `json:"retired"`
+	Reference    string        `json:"reference"`
+	ReferenceURL string        `json:"reference_url"`
+	Note         string        `json:"note"`
+	Retired      bool          `json:"retired"`
 }
 
 // ControlJSON represents an SP 800-53 control reference
@@ -167,9 +208,9 @@ type KSIDocument struct {
 
 // RequirementsDocument represents a generic requirements document (VDR, UCM, RSC, etc.)
 type RequirementsDocument struct {
-	Schema string       `json:"$schema"`
-	ID     string       `json:"$id"`
-	Info   DocumentInfo `json:"info"`
+	Schema string                                    `json:"$schema"`
+	ID     string                                    `json:"$id"`
+	Info   DocumentInfo                              `json:"info"`
 	FRR    map[string]map[string]RequirementCategory `json:"FRR"`
 }
 
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/cache"
+	"github.com/ethanolivertroy/fedramp-tui/internal/schema"
+)
+
+// schemaTimeout bounds how long fetching a declared schema may take
+const schemaTimeout = 30 * time.Second
+
+// Validation is the result of checking a document against its JSON Schema
+type Validation struct {
+	Schema      string // URL of the schema the document was checked against, or "bundled"
+	SchemaError error  // Why the declared schema could not be used, if it was not
+	Violations  []schema.Violation
+}
+
+// Valid returns true if the document matched its schema
+func (v Validation) Valid() bool {
+	return len(v.Violations) == 0
+}
+
+// ValidateDocument checks a document against the schema declared in its
+// $schema field, falling back to the bundled FRMR schema when none is
+// declared or it cannot be retrieved. Declared schemas are only fetched when
+// documents come from an HTTP source, so local sources never touch the network.
+func (c *Client) ValidateDocument(ctx context.Context, data []byte) Validation {
+	result := Validation{Schema: schema.BundledID}
+	s := schema.Bundled()
+
+	declared := schema.Declared(data)
+	src, remote := c.source.(*HTTPSource)
+	if remote && (strings.HasPrefix(declared, "http://") || strings.HasPrefix(declared, "https://")) {
+		if !schema.IsMetaSchema(declared) {
+			if fetched, err := c.schemaFor(ctx, src, declared); err != nil {
+				result.SchemaError = err
+			} else {
+				s, result.Schema = fetched, declared
+			}
+		}
+	}
+
+	violations, err := s.Validate(data)
+	if err != nil {
+		violations = []schema.Violation{{Message: fmt.Sprintf("document is not valid JSON: %v", err)}}
+	}
+	result.Violations = violations
+	return result
+}
+
+// schemaFor returns the parsed schema at url, fetching it at most once per
+// client and caching it like a document. Failures are remembered too, so an
+// unreachable schema costs one timeout rather than one per document.
+func (c *Client) schemaFor(ctx context.Context, src *HTTPSource, url string) (*schema.Schema, error) {
+	c.schemaMu.Lock()
+	defer c.schemaMu.Unlock()
+
+	if s, ok := c.schemas[url]; ok {
+		return s, nil
+	}
+	if err, ok := c.schemaErrs[url]; ok {
+		return nil, err
+	}
+
+	s, err := c.loadSchema(ctx, src, url)
+	if err != nil {
+		if c.schemaErrs == nil {
+			c.schemaErrs = make(map[string]error)
+		}
+		c.schemaErrs[url] = err
+		return nil, err
+	}
+	if c.schemas == nil {
+		c.schemas = make(map[string]*schema.Schema)
+	}
+	c.schemas[url] = s
+	return s, nil
+}
+
+// loadSchema reads a schema from the cache or fetches it from url
+func (c *Client) loadSchema(ctx context.Context, src *HTTPSource, url string) (*schema.Schema, error) {
+	var entry *cache.Entry
+	if c.cache != nil {
+		entry, _ = c.cache.Lookup(url)
+	}
+
+	var data []byte
+	if entry != nil && !c.refresh && !entry.Expired(c.cache.TTL) {
+		data = entry.Data
+	} else {
+		fetched, err := c.fetchURL(ctx, src, url)
+		switch {
+		case err == nil:
+			data = fetched
+			if c.cache != nil {
+				_ = c.cache.Put(url, data, cache.Metadata{FetchedAt: time.Now(), SourceURL: url})
+			}
+		case entry != nil:
+			data = entry.Data
+		default:
+			return nil, fmt.Errorf("fetching schema %s: %w", url, err)
+		}
+	}
+
+	s, err := schema.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", url, err)
+	}
+	return s, nil
+}
+
+// fetchURL downloads a single file with the source's HTTP client, retrying
+// like document fetches, within schemaTimeout overall
+func (c *Client) fetchURL(ctx context.Context, src *HTTPSource, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, schemaTimeout)
+	defer cancel()
+
+	for attempt := 0; ; attempt++ {
+		data, err := src.get(ctx, url)
+		if err == nil {
+			return data, nil
+		}
+		delay, ok := c.retryDelay(err, attempt)
+		if !ok {
+			return nil, err
+		}
+		if err := c.sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
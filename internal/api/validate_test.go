@@ -0,0 +1,103 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/cache"
+)
+
+func TestValidateDocument(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/remote-ref.json" {
+			_, _ = w.Write([]byte(`{"properties": {"info": {"$ref": "https://example.com/info.json"}}}`))
+			return
+		}
+		if r.URL.Path != "/frmr.schema.json" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"type": "object", "required": ["info", "FRR"]}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(WithCache(&cache.Cache{Dir: t.TempDir(), TTL: time.Hour}))
+
+	// The declared schema is fetched once and used instead of the bundled one
+	doc := []byte(`{"$schema": "` + srv.URL + `/frmr.schema.json", "info": {"name": "X", "short_name": "X"}}`)
+	for range 2 {
+		v := client.ValidateDocument(t.Context(), doc)
+		if v.Schema != srv.URL+"/frmr.schema.json" || v.SchemaError != nil {
+			t.Fatalf("Expected declared schema to be used, got %q (%v)", v.Schema, v.SchemaError)
+		}
+		if len(v.Violations) != 1 || v.Violations[0].String() != `/: missing property 'FRR'` {
+			t.Errorf("Unexpected violations %v", v.Violations)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected schema to be fetched once, got %d requests", requests)
+	}
+
+	// An unavailable schema falls back to the bundled schema
+	doc = []byte(`{"$schema": "` + srv.URL + `/missing.json", "info": {"name": "X"}}`)
+	v := client.ValidateDocument(t.Context(), doc)
+	if v.Schema != "bundled" || v.SchemaError == nil {
+		t.Errorf("Expected bundled schema with an error, got %q (%v)", v.Schema, v.SchemaError)
+	}
+	if v.Valid() {
+		t.Error("Expected bundled schema to report the missing short_name")
+	}
+	// The failure is remembered rather than retried for every document
+	before := requests
+	if v := client.ValidateDocument(t.Context(), doc); v.SchemaError == nil || requests != before {
+		t.Errorf("Expected the remembered failure without a request, got %d requests (%v)", requests-before, v.SchemaError)
+	}
+
+	// A schema with a reference that cannot be followed is not used
+	doc = []byte(`{"$schema": "` + srv.URL + `/remote-ref.json", "info": {"name": "X", "short_name": "X"}}`)
+	if v := client.ValidateDocument(t.Context(), doc); v.Schema != "bundled" || v.SchemaError == nil || !strings.Contains(v.SchemaError.Error(), "$ref") {
+		t.Errorf("Expected the bundled schema with a $ref error, got %q (%v)", v.Schema, v.SchemaError)
+	}
+
+	// Local sources use the bundled schema without touching the network
+	src, _ := NewDirSource(t.TempDir())
+	local := NewClient(WithSource(src), WithCache(nil))
+	doc = []byte(`{"$schema": "` + srv.URL + `/frmr.schema.json", "info": {"name": "X", "short_name": "X"}}`)
+	before = requests
+	if v := local.ValidateDocument(t.Context(), doc); v.Schema != "bundled" || v.SchemaError != nil || requests != before {
+		t.Errorf("Expected the bundled schema without a request, got %q (%v) after %d requests", v.Schema, v.SchemaError, requests-before)
+	}
+
+	// A JSON Schema dialect URL is not the document's schema
+	doc = []byte(`{"$schema": "https://json-schema.org/draft/2020-12/schema", "info": {"name": "X", "short_name": "X"}}`)
+	if v := client.ValidateDocument(t.Context(), doc); v.Schema != "bundled" || v.SchemaError != nil || !v.Valid() {
+		t.Errorf("Expected valid document against bundled schema, got %+v", v)
+	}
+}
+
+func TestLoadReportsSchemaViolations(t *testing.T) {
+	dir := writeFixtureDir(t)
+	src, _ := NewDirSource(dir)
+	client := NewClient(WithSource(src), WithCache(nil))
+
+	results := client.Load([]string{"FRD"})
+	if !results[0].Validation.Valid() {
+		t.Errorf("Expected fixture to be valid, got %v", results[0].Validation.Violations)
+	}
+
+	doc := `{"info": {"name": "VDR"}, "FRR": {"VDR": {"base": {"requirements": [{"id": 1}]}}}}`
+	if err := os.WriteFile(filepath.Join(dir, DocumentFiles["VDR"].Filename), []byte(doc), 0644); err != nil {
+		t.Fatal(err)
+	}
+	results = client.Load([]string{"VDR"})
+	if got := len(results[0].Validation.Violations); got != 2 {
+		t.Errorf("Expected 2 violations, got %v", results[0].Validation.Violations)
+	}
+}
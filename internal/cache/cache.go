@@ -3,6 +3,7 @@ package cache
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"time"
@@ -17,6 +18,26 @@ type Cache struct {
 // DefaultTTL is the default cache time-to-live
 const DefaultTTL = 24 * time.Hour
 
+// Metadata describes the response a cache entry was stored from
+type Metadata struct {
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	FetchedAt    time.Time `json:"fetched_at"`
+	SourceURL    string    `json:"source_url"`
+	Ref          string    `json:"ref,omitempty"` // Git ref the entry was fetched from, if any
+}
+
+// Entry is a cached document together with its metadata
+type Entry struct {
+	Data []byte
+	Metadata
+}
+
+// Expired returns true if the entry was fetched (or last revalidated) more than ttl ago
+func (e *Entry) Expired(ttl time.Duration) bool {
+	return time.Since(e.FetchedAt) > ttl
+}
+
 // New creates a new cache with the default directory (~/.cache/fedramp-tui)
 func New() (*Cache, error) {
 	homeDir, err := os.UserHomeDir()
@@ -46,8 +67,16 @@ func (c *Cache) Path(key string) string {
 	return filepath.Join(c.Dir, c.keyToFilename(key))
 }
 
-// Get retrieves data from cache if it exists and is not expired
-func (c *Cache) Get(key string) ([]byte, bool) {
+// metaPath returns the path of the metadata file stored next to a cache file
+func (c *Cache) metaPath(key string) string {
+	path := c.Path(key)
+	return path[:len(path)-len(".json")] + ".meta.json"
+}
+
+// Lookup returns the cache entry for a key whether or not it has expired.
+// Entries written before metadata was recorded use the file mtime as their
+// fetch time.
+func (c *Cache) Lookup(key string) (*Entry, bool) {
 	path := c.Path(key)
 
 	info, err := os.Stat(path)
@@ -55,23 +84,62 @@ func (c *Cache) Get(key string) ([]byte, bool) {
 		return nil, false
 	}
 
-	// Check if cache is expired
-	if time.Since(info.ModTime()) > c.TTL {
-		return nil, false
-	}
-
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, false
 	}
 
-	return data, true
+	entry := &Entry{Data: data}
+	if raw, err := os.ReadFile(c.metaPath(key)); err == nil {
+		_ = json.Unmarshal(raw, &entry.Metadata)
+	}
+	if entry.FetchedAt.IsZero() {
+		entry.FetchedAt = info.ModTime()
+	}
+	if entry.SourceURL == "" {
+		entry.SourceURL = key
+	}
+
+	return entry, true
+}
+
+// Get retrieves data from cache if it exists and is not expired
+func (c *Cache) Get(key string) ([]byte, bool) {
+	entry, ok := c.Lookup(key)
+	if !ok || entry.Expired(c.TTL) {
+		return nil, false
+	}
+	return entry.Data, true
 }
 
 // Set stores data in the cache
 func (c *Cache) Set(key string, data []byte) error {
-	path := c.Path(key)
-	return os.WriteFile(path, data, 0644)
+	return c.Put(key, data, Metadata{FetchedAt: time.Now(), SourceURL: key})
+}
+
+// Put stores data in the cache along with its response metadata
+func (c *Cache) Put(key string, data []byte, meta Metadata) error {
+	if err := os.WriteFile(c.Path(key), data, 0644); err != nil {
+		return err
+	}
+	return c.writeMeta(key, meta)
+}
+
+// Renew replaces the metadata of an existing entry without rewriting its
+// data, e.g. after the server answered a conditional request with 304
+func (c *Cache) Renew(key string, meta Metadata) error {
+	if _, err := os.Stat(c.Path(key)); err != nil {
+		return err
+	}
+	return c.writeMeta(key, meta)
+}
+
+func (c *Cache) writeMeta(key string, meta Metadata) error {
+	raw, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.metaPath(key), raw, 0644)
 }
 
 // Clear removes all cached files
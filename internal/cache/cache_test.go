@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestGetHonorsTTL(t *testing.T) {
+	c := &Cache{Dir: t.TempDir(), TTL: time.Hour}
+	if err := c.Set("key", []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+	if data, ok := c.Get("key"); !ok || string(data) != "data" {
+		t.Fatalf("Expected fresh entry, got %q %v", data, ok)
+	}
+
+	old := Metadata{FetchedAt: time.Now().Add(-2 * time.Hour), SourceURL: "key"}
+	if err := c.Renew("key", old); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get("key"); ok {
+		t.Error("Expected expired entry to be a miss")
+	}
+	if entry, ok := c.Lookup("key"); !ok || string(entry.Data) != "data" {
+		t.Error("Expected Lookup to return expired entries")
+	}
+}
+
+func TestLookupWithoutMetadata(t *testing.T) {
+	c := &Cache{Dir: t.TempDir(), TTL: time.Hour}
+	// Entries written by older versions have no metadata file
+	if err := os.WriteFile(c.Path("key"), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	entry, ok := c.Lookup("key")
+	if !ok {
+		t.Fatal("Expected entry")
+	}
+	if entry.FetchedAt.IsZero() || entry.SourceURL != "key" {
+		t.Errorf("Expected fallback metadata, got %+v", entry.Metadata)
+	}
+}
+
+func TestRenewRequiresEntry(t *testing.T) {
+	c := &Cache{Dir: t.TempDir(), TTL: time.Hour}
+	if err := c.Renew("missing", Metadata{FetchedAt: time.Now()}); err == nil {
+		t.Error("Expected error renewing a missing entry")
+	}
+}
@@ -0,0 +1,305 @@
+// Package catalog loads NIST SP 800-53 controls from an OSCAL catalog, so
+// that KSI control mappings can be shown with the full control text. A pinned
+// copy of the Rev5 catalog is embedded when data/catalog.json.gz is checked in;
+// see gen.go.
+package catalog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+)
+
+//go:embed data
+var files embed.FS
+
+// bundledFile is the embedded catalog written by gen.go
+const bundledFile = "data/catalog.json.gz"
+
+// ErrNotBundled is returned by Bundled when the binary was built without a catalog
+var ErrNotBundled = errors.New("no SP 800-53 catalog bundled with this build")
+
+// Catalog is a parsed OSCAL control catalog
+type Catalog struct {
+	Title   string
+	Version string
+	Source  string // Path the catalog was loaded from, or "bundled"
+
+	controls []model.CatalogControl // In catalog order, each control followed by its enhancements
+	index    map[string]int         // OSCAL ID
+}
+
+// Load reads an OSCAL catalog JSON file, optionally gzip-compressed
+func Load(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	c.Source = path
+	return c, nil
+}
+
+// Bundled returns the catalog embedded in the binary
+func Bundled() (*Catalog, error) {
+	data, err := files.ReadFile(bundledFile)
+	if err != nil {
+		return nil, ErrNotBundled
+	}
+	c, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing bundled catalog: %w", err)
+	}
+	c.Source = "bundled"
+	return c, nil
+}
+
+// OSCAL JSON structures, limited to the fields shown in the TUI
+type (
+	document struct {
+		Catalog *struct {
+			Metadata struct {
+				Title   string `json:"title"`
+				Version string `json:"version"`
+			} `json:"metadata"`
+			Groups   []group   `json:"groups"`
+			Controls []control `json:"controls"`
+		} `json:"catalog"`
+	}
+
+	group struct {
+		ID       string    `json:"id"`
+		Title    string    `json:"title"`
+		Groups   []group   `json:"groups"`
+		Controls []control `json:"controls"`
+	}
+
+	control struct {
+		ID       string    `json:"id"`
+		Title    string    `json:"title"`
+		Params   []param   `json:"params"`
+		Props    []prop    `json:"props"`
+		Parts    []part    `json:"parts"`
+		Controls []control `json:"controls"`
+	}
+
+	part struct {
+		Name  string `json:"name"`
+		Prose string `json:"prose"`
+		Props []prop `json:"props"`
+		Parts []part `json:"parts"`
+	}
+
+	param struct {
+		ID     string `json:"id"`
+		Label  string `json:"label"`
+		Select *struct {
+			HowMany string   `json:"how-many"`
+			Choice  []string `json:"choice"`
+		} `json:"select"`
+		Guidelines []struct {
+			Prose string `json:"prose"`
+		} `json:"guidelines"`
+	}
+
+	prop struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+		Class string `json:"class"`
+	}
+)
+
+// Parse decodes an OSCAL catalog, optionally gzip-compressed
+func Parse(data []byte) (*Catalog, error) {
+	if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		if data, err = io.ReadAll(gz); err != nil {
+			return nil, err
+		}
+	}
+
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Catalog == nil {
+		return nil, errors.New("not an OSCAL catalog: missing \"catalog\"")
+	}
+
+	c := &Catalog{
+		Title:   doc.Catalog.Metadata.Title,
+		Version: doc.Catalog.Metadata.Version,
+		index:   make(map[string]int),
+	}
+
+	// Parameters are resolved across the whole catalog, since statements and
+	// selections can insert parameters defined elsewhere
+	params := make(map[string]param)
+	var collect func(controls []control)
+	collect = func(controls []control) {
+		for _, ctrl := range controls {
+			for _, p := range ctrl.Params {
+				params[p.ID] = p
+			}
+			collect(ctrl.Controls)
+		}
+	}
+	var collectGroups func(groups []group)
+	collectGroups = func(groups []group) {
+		for _, g := range groups {
+			collect(g.Controls)
+			collectGroups(g.Groups)
+		}
+	}
+	collect(doc.Catalog.Controls)
+	collectGroups(doc.Catalog.Groups)
+
+	r := resolver{params: params}
+	var addGroups func(groups []group)
+	addGroups = func(groups []group) {
+		for _, g := range groups {
+			for _, ctrl := range g.Controls {
+				c.add(r, ctrl, g, "")
+			}
+			addGroups(g.Groups)
+		}
+	}
+	for _, ctrl := range doc.Catalog.Controls {
+		c.add(r, ctrl, group{}, "")
+	}
+	addGroups(doc.Catalog.Groups)
+	return c, nil
+}
+
+// add appends a control and, after it, its enhancements
+func (c *Catalog) add(r resolver, ctrl control, g group, parent string) {
+	cc := model.CatalogControl{
+		ID:          ctrl.ID,
+		Label:       label(ctrl.Props),
+		Title:       ctrl.Title,
+		Family:      g.ID,
+		FamilyTitle: g.Title,
+		Parent:      parent,
+	}
+	if cc.Label == "" {
+		cc.Label = LabelFor(ctrl.ID)
+	}
+	for _, p := range ctrl.Props {
+		if p.Name == "status" && p.Value == "withdrawn" {
+			cc.Withdrawn = true
+		}
+	}
+	for _, p := range ctrl.Params {
+		cc.Params = append(cc.Params, r.param(p, 0))
+	}
+	for _, p := range ctrl.Parts {
+		switch p.Name {
+		case "statement":
+			cc.Statement = r.statement(p, 0, cc.Statement)
+		case "guidance":
+			cc.Guidance = r.text(p.Prose, 0)
+		}
+	}
+	for _, enh := range ctrl.Controls {
+		cc.Enhancements = append(cc.Enhancements, enh.ID)
+	}
+
+	if _, ok := c.index[cc.ID]; !ok {
+		c.index[cc.ID] = len(c.controls)
+	}
+	c.controls = append(c.controls, cc)
+
+	for _, enh := range ctrl.Controls {
+		c.add(r, enh, g, ctrl.ID)
+	}
+}
+
+// label returns the display label from a control or part's props, ignoring
+// alternative forms such as zero-padded labels
+func label(props []prop) string {
+	for _, p := range props {
+		if p.Name == "label" && p.Class == "" {
+			return p.Value
+		}
+	}
+	return ""
+}
+
+// insertPattern matches OSCAL parameter insertions, e.g. "{{ insert: param, ac-02_odp.01 }}"
+var insertPattern = regexp.MustCompile(`\{\{\s*insert:\s*param,\s*([^\s}]+)\s*\}\}`)
+
+// resolver fills in parameter insertions with their placeholders
+type resolver struct {
+	params map[string]param
+}
+
+// text replaces every parameter insertion in s. Selections can themselves
+// insert parameters, so depth guards against cycles.
+func (r resolver) text(s string, depth int) string {
+	return insertPattern.ReplaceAllStringFunc(s, func(m string) string {
+		id := insertPattern.FindStringSubmatch(m)[1]
+		p, ok := r.params[id]
+		if !ok || depth > 3 {
+			return "[Assignment: " + id + "]"
+		}
+		return r.param(p, depth+1).Placeholder()
+	})
+}
+
+// param converts a parameter, filling in insertions in its label and choices
+func (r resolver) param(p param, depth int) model.ControlParam {
+	mp := model.ControlParam{ID: p.ID, Label: r.text(p.Label, depth)}
+	if p.Select != nil {
+		mp.HowMany = p.Select.HowMany
+		for _, choice := range p.Select.Choice {
+			mp.Choices = append(mp.Choices, r.text(choice, depth))
+		}
+	}
+	for _, g := range p.Guidelines {
+		mp.Guidelines = append(mp.Guidelines, r.text(g.Prose, depth))
+	}
+	return mp
+}
+
+// statement flattens a statement part and its items
+func (r resolver) statement(p part, depth int, out []model.StatementPart) []model.StatementPart {
+	if prose := strings.TrimSpace(p.Prose); prose != "" {
+		out = append(out, model.StatementPart{Label: label(p.Props), Prose: r.text(prose, 0), Depth: depth})
+	}
+	for _, child := range p.Parts {
+		if child.Name == "item" || child.Name == "statement" {
+			out = r.statement(child, depth+1, out)
+		}
+	}
+	return out
+}
+
+// Controls returns every control in catalog order, each base control
+// followed by its enhancements
+func (c *Catalog) Controls() []model.CatalogControl {
+	return c.controls
+}
+
+// Control looks up a control by its OSCAL ID ("ac-2.1") or label ("AC-2(1)")
+func (c *Catalog) Control(id string) (model.CatalogControl, bool) {
+	i, ok := c.index[OSCALID(id)]
+	if !ok {
+		return model.CatalogControl{}, false
+	}
+	return c.controls[i], true
+}
@@ -0,0 +1,149 @@
+package catalog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"slices"
+	"testing"
+)
+
+const testCatalog = `{"catalog": {
+	"metadata": {"title": "NIST SP 800-53 Rev 5", "version": "5.1.1"},
+	"groups": [{"id": "ac", "class": "family", "title": "Access Control", "controls": [
+		{"id": "ac-2", "title": "Account Management",
+		 "params": [
+			{"id": "ac-02_odp.01", "label": "prerequisites and criteria"},
+			{"id": "ac-02_odp.02", "select": {"how-many": "one-or-more", "choice": ["disabled", "removed within {{ insert: param, ac-02_odp.03 }}"]}},
+			{"id": "ac-02_odp.03", "label": "time period", "guidelines": [{"prose": "as short as practical"}]}
+		 ],
+		 "props": [{"name": "label", "value": "AC-2"}, {"name": "label", "class": "zero-padded", "value": "AC-02"}],
+		 "parts": [
+			{"name": "statement", "parts": [
+				{"name": "item", "props": [{"name": "label", "value": "a."}], "prose": "Require {{ insert: param, ac-02_odp.01 }} for group membership;",
+				 "parts": [{"name": "item", "props": [{"name": "label", "value": "1."}], "prose": "Accounts are {{ insert: param, ac-02_odp.02 }}."}]}
+			]},
+			{"name": "guidance", "prose": "Examples of system account types include individual and shared."}
+		 ],
+		 "controls": [
+			{"id": "ac-2.1", "title": "Automated System Account Management",
+			 "props": [{"name": "label", "value": "AC-2(1)"}],
+			 "parts": [{"name": "statement", "prose": "Support account management using automated mechanisms."}]},
+			{"id": "ac-2.10", "title": "Shared and Group Account Credential Change",
+			 "props": [{"name": "label", "value": "AC-2(10)"}, {"name": "status", "value": "withdrawn"}]}
+		 ]}
+	]}]
+}}`
+
+func TestParse(t *testing.T) {
+	c, err := Parse([]byte(testCatalog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Title != "NIST SP 800-53 Rev 5" || c.Version != "5.1.1" {
+		t.Errorf("Unexpected metadata %q %q", c.Title, c.Version)
+	}
+
+	var labels []string
+	for _, ctrl := range c.Controls() {
+		labels = append(labels, ctrl.Label)
+	}
+	// Enhancements follow their base control
+	if want := []string{"AC-2", "AC-2(1)", "AC-2(10)"}; !slices.Equal(labels, want) {
+		t.Errorf("Expected %v, got %v", want, labels)
+	}
+
+	ac2, ok := c.Control("AC-2")
+	if !ok {
+		t.Fatal("Expected AC-2 to be found")
+	}
+	if ac2.Family != "ac" || ac2.FamilyTitle != "Access Control" || ac2.IsEnhancement() {
+		t.Errorf("Unexpected AC-2 %+v", ac2)
+	}
+	if !slices.Equal(ac2.Enhancements, []string{"ac-2.1", "ac-2.10"}) {
+		t.Errorf("Unexpected enhancements %v", ac2.Enhancements)
+	}
+	if len(ac2.Statement) != 2 {
+		t.Fatalf("Expected 2 statement items, got %+v", ac2.Statement)
+	}
+	if got := ac2.Statement[0]; got.Label != "a." || got.Depth != 1 || got.Prose != "Require [Assignment: prerequisites and criteria] for group membership;" {
+		t.Errorf("Unexpected statement item %+v", got)
+	}
+	// Selections can insert parameters of their own
+	if got, want := ac2.Statement[1].Prose, "Accounts are [Selection (one or more): disabled; removed within [Assignment: time period]]."; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if len(ac2.Params) != 3 || ac2.Params[2].Guidelines[0] != "as short as practical" {
+		t.Errorf("Unexpected params %+v", ac2.Params)
+	}
+	if ac2.Guidance == "" {
+		t.Error("Expected guidance")
+	}
+
+	enh, ok := c.Control("ac-2.1")
+	if !ok || enh.Parent != "ac-2" || enh.Statement[0].Prose != "Support account management using automated mechanisms." {
+		t.Errorf("Unexpected AC-2(1) %+v", enh)
+	}
+	if withdrawn, _ := c.Control("AC-2(10)"); !withdrawn.Withdrawn {
+		t.Error("Expected AC-2(10) to be withdrawn")
+	}
+}
+
+func TestParseGzip(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, _ = gz.Write([]byte(testCatalog))
+	_ = gz.Close()
+
+	c, err := Parse(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Controls()) != 3 {
+		t.Errorf("Expected 3 controls, got %d", len(c.Controls()))
+	}
+
+	if _, err := Parse([]byte(`{"profile": {}}`)); err == nil {
+		t.Error("Expected an error for a document that is not a catalog")
+	}
+}
+
+func TestControlIDs(t *testing.T) {
+	tests := []struct {
+		in, oscal, label string
+	}{
+		{"ac-2", "ac-2", "AC-2"},
+		{"AC-2", "ac-2", "AC-2"},
+		{"ac-2.1", "ac-2.1", "AC-2(1)"},
+		{"AC-2(1)", "ac-2.1", "AC-2(1)"},
+		{"AC-02 (01)", "ac-2.1", "AC-2(1)"},
+		{" sc-7.12 ", "sc-7.12", "SC-7(12)"},
+		{"not-a-control", "not-a-control", "NOT-A-CONTROL"},
+	}
+	for _, tt := range tests {
+		if got := OSCALID(tt.in); got != tt.oscal {
+			t.Errorf("OSCALID(%q) = %q, want %q", tt.in, got, tt.oscal)
+		}
+		if got := LabelFor(tt.in); got != tt.label {
+			t.Errorf("LabelFor(%q) = %q, want %q", tt.in, got, tt.label)
+		}
+	}
+}
+
+func TestFamilyAndCompare(t *testing.T) {
+	if got := Family("ac-2.1"); got != "AC" {
+		t.Errorf("Family(ac-2.1) = %q, want AC", got)
+	}
+	ids := []string{"AC-10", "ac-2.10", "SC-7", "AC-2(1)", "ac-2"}
+	slices.SortFunc(ids, Compare)
+	if want := []string{"ac-2", "AC-2(1)", "ac-2.10", "AC-10", "SC-7"}; !slices.Equal(ids, want) {
+		t.Errorf("Expected %v, got %v", want, ids)
+	}
+}
+
+func TestBundled(t *testing.T) {
+	// Development builds embed no catalog
+	if _, err := Bundled(); err != nil && !errors.Is(err, ErrNotBundled) {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,80 @@
+//go:build ignore
+
+// gen downloads the NIST SP 800-53 Rev5 OSCAL catalog into
+// data/catalog.json.gz. Run it with
+// `go run gen.go -ref <tag|sha>` in internal/catalog and commit data/, so the
+// bundled catalog is pinned and every build embeds the same copy.
+package main
+
+import (
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/catalog"
+)
+
+const catalogURL = "https://raw.githubusercontent.com/usnistgov/oscal-content/%s/nist.gov/SP800-53/rev5/json/NIST_SP-800-53_rev5_catalog.json"
+
+func main() {
+	ref := flag.String("ref", "", "usnistgov/oscal-content tag or commit to embed the catalog from")
+	flag.Parse()
+
+	// A branch would make the embedded catalog depend on when gen ran
+	if *ref == "" || *ref == "main" {
+		fmt.Fprintln(os.Stderr, "catalog: -ref must name a tag or commit of usnistgov/oscal-content")
+		os.Exit(2)
+	}
+	if err := run(fmt.Sprintf(catalogURL, *ref)); err != nil {
+		fmt.Fprintf(os.Stderr, "catalog: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(url string) error {
+	client := &http.Client{Timeout: 120 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// Refuse to embed something the TUI cannot read
+	c, err := catalog.Parse(data)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", url, err)
+	}
+
+	path := filepath.Join("data", "catalog.json.gz")
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	gz, _ := gzip.NewWriterLevel(f, gzip.BestCompression)
+	if _, err := gz.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	fmt.Printf("catalog: embedded %s (%s, %d controls)\n", c.Title, c.Version, len(c.Controls()))
+	return nil
+}
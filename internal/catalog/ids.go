@@ -0,0 +1,91 @@
+package catalog
+
+import (
+	"cmp"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// labelPattern matches control labels and OSCAL IDs, e.g. "AC-2(1)",
+// "ac-2.1" or "AC-02 (01)"
+var labelPattern = regexp.MustCompile(`^([A-Za-z]{2})-0*(\d+)(?:\s*\(0*(\d+)\)|\.0*(\d+))?$`)
+
+// OSCALID converts a control label or ID to its OSCAL ID, e.g. "AC-2(1)" to
+// "ac-2.1". IDs it does not recognize are lowercased.
+func OSCALID(id string) string {
+	id = strings.TrimSpace(id)
+	m := labelPattern.FindStringSubmatch(id)
+	if m == nil {
+		return strings.ToLower(id)
+	}
+	out := strings.ToLower(m[1]) + "-" + m[2]
+	if enh := m[3] + m[4]; enh != "" {
+		out += "." + enh
+	}
+	return out
+}
+
+// LabelFor converts a control label or OSCAL ID to the SP 800-53 display
+// label, e.g. "ac-2.1" to "AC-2(1)". IDs it does not recognize are uppercased.
+func LabelFor(id string) string {
+	id = strings.TrimSpace(id)
+	m := labelPattern.FindStringSubmatch(id)
+	if m == nil {
+		return strings.ToUpper(id)
+	}
+	out := strings.ToUpper(m[1]) + "-" + m[2]
+	if enh := m[3] + m[4]; enh != "" {
+		out += "(" + enh + ")"
+	}
+	return out
+}
+
+// Families are the SP 800-53 Rev5 control families, by family ID
+var Families = map[string]string{
+	"AC": "Access Control",
+	"AT": "Awareness and Training",
+	"AU": "Audit and Accountability",
+	"CA": "Assessment, Authorization, and Monitoring",
+	"CM": "Configuration Management",
+	"CP": "Contingency Planning",
+	"IA": "Identification and Authentication",
+	"IR": "Incident Response",
+	"MA": "Maintenance",
+	"MP": "Media Protection",
+	"PE": "Physical and Environmental Protection",
+	"PL": "Planning",
+	"PM": "Program Management",
+	"PS": "Personnel Security",
+	"PT": "PII Processing and Transparency",
+	"RA": "Risk Assessment",
+	"SA": "System and Services Acquisition",
+	"SC": "System and Communications Protection",
+	"SI": "System and Information Integrity",
+	"SR": "Supply Chain Risk Management",
+}
+
+// Family returns the family ID of a control, e.g. "AC" for "ac-2.1"
+func Family(id string) string {
+	family, _, _ := strings.Cut(LabelFor(id), "-")
+	return family
+}
+
+// Compare orders controls by family, then control number, then enhancement
+// number, so that AC-2 < AC-2(1) < AC-2(10) < AC-10
+func Compare(a, b string) int {
+	ma := labelPattern.FindStringSubmatch(strings.TrimSpace(a))
+	mb := labelPattern.FindStringSubmatch(strings.TrimSpace(b))
+	if ma == nil || mb == nil {
+		return strings.Compare(LabelFor(a), LabelFor(b))
+	}
+	num := func(s string) int {
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+	return cmp.Or(
+		strings.Compare(strings.ToUpper(ma[1]), strings.ToUpper(mb[1])),
+		cmp.Compare(num(ma[2]), num(mb[2])),
+		cmp.Compare(num(ma[3]+ma[4]), num(mb[3]+mb[4])),
+	)
+}
@@ -0,0 +1,157 @@
+// Package cli implements the non-interactive fedramp subcommands
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/api"
+	"github.com/ethanolivertroy/fedramp-tui/internal/store"
+)
+
+// Process exit codes
+const (
+	ExitOK       = 0
+	ExitFailure  = 1 // The command ran but found problems, e.g. schema violations
+	ExitUsage    = 2 // Invalid arguments
+	ExitNotFound = 3 // Nothing matched the query or ID
+)
+
+// env is what every command runs with
+type env struct {
+	ctx    context.Context
+	client *api.Client
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// command is a subcommand such as "validate"
+type command struct {
+	summary string
+	run     func(e *env, args []string) int
+}
+
+var commands = map[string]command{
+	"validate":    {"Validate documents against their JSON Schema", runValidate},
+	"list":        {"List documents, requirements, definitions or indicators", runList},
+	"show":        {"Show a requirement, indicator, definition, document or control by ID", runShow},
+	"search":      {"Search requirements, indicators and definitions for text", runSearch},
+	"definitions": {"List definitions (same as list definitions)", runDefinitions},
+	"indicators":  {"List Key Security Indicators, optionally of one --theme", runIndicators},
+	"export":      {"Export data to CSV or XLSX, or a document to Markdown or HTML", runExport},
+	"site":        {"Generate a static HTML site of every document", runSite},
+}
+
+// IsCommand returns true if name is a subcommand
+func IsCommand(name string) bool {
+	_, ok := commands[name]
+	return ok
+}
+
+// Run executes the subcommand named by args[0] and returns the process exit code
+func Run(ctx context.Context, client *api.Client, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		Usage(stderr)
+		return ExitUsage
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "Error: unknown command %q\n\n", args[0])
+		Usage(stderr)
+		return ExitUsage
+	}
+	return cmd.run(&env{ctx: ctx, client: client, stdout: stdout, stderr: stderr}, args[1:])
+}
+
+// Usage lists the available subcommands
+func Usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "Commands:")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-12s %s\n", name, commands[name].summary)
+	}
+}
+
+// newFlagSet returns a flag set for a subcommand that reports errors instead
+// of exiting
+func (e *env) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("fedramp "+name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	return fs
+}
+
+// parseArgs parses flags placed anywhere among the arguments, so that both
+// "show --output json ID" and "show ID --output json" work, and returns the
+// remaining positional arguments
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// loadDocuments discovers and loads the given documents, or all of them. It
+// fails without fetching anything if a code is not a known document.
+func (e *env) loadDocuments(codes []string) ([]api.DocumentResult, error) {
+	// A failed listing falls back to the last known document list
+	_ = e.client.DiscoverContext(e.ctx)
+	known := e.client.DocumentCodes()
+	if len(codes) == 0 {
+		codes = known
+	}
+	for _, code := range codes {
+		if !slices.Contains(known, code) {
+			return nil, fmt.Errorf("unknown document %s", code)
+		}
+	}
+	return e.client.LoadContext(e.ctx, codes), nil
+}
+
+// loadStore loads the given documents, or all of them, and indexes them.
+// Documents that fail to load are reported on stderr. On failure it returns
+// the exit code: ExitNotFound for an unknown document, or ExitFailure if no
+// document could be loaded.
+func (e *env) loadStore(codes []string) (*store.Store, int) {
+	results, err := e.loadDocuments(codes)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "Error: %v\n", err)
+		return nil, ExitNotFound
+	}
+	failed := 0
+	for _, res := range results {
+		switch {
+		case res.Error != nil:
+			fmt.Fprintf(e.stderr, "warning: %s: fetch failed: %v\n", res.Code, res.Error)
+		case res.ParseError != nil:
+			fmt.Fprintf(e.stderr, "warning: %s: %v\n", res.Code, res.ParseError)
+		default:
+			if res.Stale() {
+				fmt.Fprintf(e.stderr, "warning: %s: source unavailable, using cached copy from %s\n", res.Code, res.FetchedAt.Format("2006-01-02 15:04"))
+			}
+			continue
+		}
+		failed++
+	}
+	if failed == len(results) {
+		fmt.Fprintln(e.stderr, "Error: no documents could be loaded")
+		return nil, ExitFailure
+	}
+	return store.FromResults(results), ExitOK
+}
@@ -0,0 +1,297 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/api"
+	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+)
+
+// newTestClient returns a client reading the given files from a temporary directory
+func newTestClient(t *testing.T, files map[string]string) *api.Client {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	src, err := api.NewDirSource(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return api.NewClient(api.WithSource(src), api.WithCache(nil), api.WithSnapshot(false), api.WithRetry(1, 0))
+}
+
+func run(t *testing.T, client *api.Client, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := Run(t.Context(), client, args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestValidate(t *testing.T) {
+	client := newTestClient(t, map[string]string{
+		"FRMR.FRD.fedramp-definitions.json":                  `{"info": {"name": "Definitions", "short_name": "FRD"}, "FRD": {"ALL": [{"id": "FRD-ALL-01", "term": "Agency", "definition": "..."}]}}`,
+		"FRMR.VDR.vulnerability-detection-and-response.json": `{"info": {"name": "VDR", "short_name": "VDR"}, "FRR": {"VDR": {"base": {"requirements": [{"id": "FRR-VDR-01", "affects": "Providers"}]}}}}`,
+	})
+
+	code, stdout, _ := run(t, client, "validate", "--doc", "frd")
+	if code != ExitOK || !strings.Contains(stdout, "FRD") || !strings.Contains(stdout, "valid") {
+		t.Errorf("Expected FRD to validate, got exit %d:\n%s", code, stdout)
+	}
+
+	code, stdout, _ = run(t, client, "validate")
+	if code != ExitFailure {
+		t.Errorf("Expected exit %d for schema violations, got %d", ExitFailure, code)
+	}
+	if !strings.Contains(stdout, "/FRR/VDR/base/requirements/0/affects: got string, want array") || !strings.Contains(stdout, "1 schema issue;") {
+		t.Errorf("Expected violation with JSON pointer, got:\n%s", stdout)
+	}
+}
+
+func TestUnknownCommand(t *testing.T) {
+	code, _, stderr := run(t, newTestClient(t, nil), "frobnicate")
+	if code != ExitUsage || !strings.Contains(stderr, "validate") {
+		t.Errorf("Expected usage error listing commands, got exit %d: %s", code, stderr)
+	}
+}
+
+// queryFiles are FRMR documents for the query commands
+var queryFiles = map[string]string{
+	"FRMR.FRD.fedramp-definitions.json": `{"info": {"name": "Definitions", "short_name": "FRD"}, "FRD": {"ALL": [
+		{"id": "FRD-ALL-01", "term": "Vulnerability", "definition": "A weakness in an information system."}
+	]}}`,
+	"FRMR.VDR.vulnerability-detection-and-response.json": `{"info": {"name": "Vulnerability Detection and Response", "short_name": "VDR"}, "FRR": {"VDR": {"base": {"requirements": [
+		{"id": "FRR-VDR-01", "name": "Detect", "statement": "Providers MUST detect each vulnerability.", "primary_key_word": "MUST", "affects": ["Providers"], "impact": {"low": true}},
+		{"id": "FRR-VDR-02", "name": "Inventory", "statement": "Agencies SHOULD keep an inventory.", "primary_key_word": "SHOULD", "affects": ["Agencies"]}
+	]}}}}`,
+	"FRMR.KSI.key-security-indicators.json": `{"info": {"name": "Key Security Indicators", "short_name": "KSI"}, "KSI": {
+		"IAM": {"name": "Identity and Access Management", "indicators": [{"id": "KSI-IAM-01", "name": "Phishing-resistant MFA", "controls": [{"control_id": "ia-2.1", "title": "Multi-factor Authentication"}]}]},
+		"CED": {"name": "Cybersecurity Education", "indicators": [{"id": "KSI-CED-01", "name": "Training"}]}
+	}}`,
+}
+
+func TestListRequirements(t *testing.T) {
+	client := newTestClient(t, queryFiles)
+
+	code, stdout, _ := run(t, client, "list", "requirements", "--doc", "vdr", "--keyword", "must", "--affects", "providers")
+	if code != ExitOK || !strings.Contains(stdout, "FRR-VDR-01") || strings.Contains(stdout, "FRR-VDR-02") {
+		t.Errorf("Expected only FRR-VDR-01, got exit %d:\n%s", code, stdout)
+	}
+	if lines := strings.Split(stdout, "\n"); !strings.HasPrefix(lines[0], "ID ") || strings.Index(lines[0], "DOCUMENT") != strings.Index(lines[1], " VDR ")+1 {
+		t.Errorf("Expected aligned columns, got:\n%s", stdout)
+	}
+
+	code, _, _ = run(t, client, "list", "requirements", "--keyword", "MAY")
+	if code != ExitNotFound {
+		t.Errorf("Expected exit %d when nothing matches, got %d", ExitNotFound, code)
+	}
+	code, _, stderr := run(t, client, "list", "requirements", "--doc", "XYZ")
+	if code != ExitNotFound || !strings.Contains(stderr, "unknown document XYZ") {
+		t.Errorf("Expected exit %d for an unknown document, got %d: %s", ExitNotFound, code, stderr)
+	}
+	code, _, _ = run(t, client, "list", "widgets")
+	if code != ExitUsage {
+		t.Errorf("Expected exit %d for an unknown kind, got %d", ExitUsage, code)
+	}
+}
+
+func TestShow(t *testing.T) {
+	client := newTestClient(t, queryFiles)
+
+	tests := []struct {
+		id   string
+		want []string
+	}{
+		{"FRR-VDR-01", []string{"Keyword:", "MUST", "Providers MUST detect each vulnerability."}},
+		{"KSI-IAM-01", []string{"Identity and Access Management", "IA-2(1) Multi-factor Authentication"}},
+		{"FRD-ALL-01", []string{"Vulnerability", "A weakness", "FRR-VDR-01"}},
+		{"vdr", []string{"Code:         VDR", "Requirements: 2"}},
+		{"IA-2(1)", []string{"Identification and Authentication", "KSI-IAM-01"}},
+	}
+	for _, tt := range tests {
+		code, stdout, stderr := run(t, client, "show", tt.id)
+		if code != ExitOK {
+			t.Errorf("show %s: exit %d: %s", tt.id, code, stderr)
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(stdout, want) {
+				t.Errorf("show %s: expected %q in:\n%s", tt.id, want, stdout)
+			}
+		}
+	}
+
+	code, _, stderr := run(t, client, "show", "FRR-VDR-99")
+	if code != ExitNotFound || !strings.Contains(stderr, "not found") {
+		t.Errorf("Expected exit %d for an unknown ID, got %d: %s", ExitNotFound, code, stderr)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	client := newTestClient(t, queryFiles)
+
+	code, stdout, _ := run(t, client, "search", "Inventory")
+	if code != ExitOK || !strings.Contains(stdout, "requirement  FRR-VDR-02") {
+		t.Errorf("Expected FRR-VDR-02 to match, got exit %d:\n%s", code, stdout)
+	}
+
+	code, _, _ = run(t, client, "search", "quantum")
+	if code != ExitNotFound {
+		t.Errorf("Expected exit %d when nothing matches, got %d", ExitNotFound, code)
+	}
+}
+
+func TestIndicatorsAndDefinitions(t *testing.T) {
+	client := newTestClient(t, queryFiles)
+
+	code, stdout, _ := run(t, client, "indicators", "--theme", "iam")
+	if code != ExitOK || !strings.Contains(stdout, "KSI-IAM-01") || strings.Contains(stdout, "KSI-CED-01") {
+		t.Errorf("Expected only the IAM theme, got exit %d:\n%s", code, stdout)
+	}
+
+	code, stdout, _ = run(t, client, "definitions")
+	if code != ExitOK || !strings.Contains(stdout, "Vulnerability") {
+		t.Errorf("Expected the definitions table, got exit %d:\n%s", code, stdout)
+	}
+}
+
+func TestOutputFormats(t *testing.T) {
+	client := newTestClient(t, queryFiles)
+
+	code, stdout, _ := run(t, client, "list", "requirements", "--doc", "VDR", "--output", "json")
+	var result struct {
+		SchemaVersion int                 `json:"schema_version"`
+		Kind          string              `json:"kind"`
+		Source        string              `json:"source"`
+		Count         int                 `json:"count"`
+		Items         []model.Requirement `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("Expected JSON, got %v:\n%s", err, stdout)
+	}
+	if code != ExitOK || result.SchemaVersion != SchemaVersion || result.Kind != "requirement" || result.Source == "" || result.Count != 2 {
+		t.Errorf("Unexpected envelope: exit %d, %+v", code, result)
+	}
+	if r := result.Items[0]; r.ID != "FRR-VDR-01" || r.PrimaryKeyWord != "MUST" || !r.Impact.Low || !slices.Equal(r.Affects, []string{"Providers"}) {
+		t.Errorf("Expected FRR-VDR-01 to round-trip, got %+v", r)
+	}
+
+	// Flags may follow the ID
+	code, stdout, _ = run(t, client, "show", "KSI-IAM-01", "--output", "yaml")
+	for _, want := range []string{"schema_version: 1\n", "kind: \"indicator\"\n", "items:\n  - id: \"KSI-IAM-01\"\n", "    controls:\n      - control_id: \"ia-2.1\"\n"} {
+		if code != ExitOK || !strings.Contains(stdout, want) {
+			t.Errorf("Expected YAML containing %q, got exit %d:\n%s", want, code, stdout)
+		}
+	}
+
+	// Empty lists are arrays, never null
+	code, stdout, _ = run(t, client, "show", "KSI-CED-01", "--output", "json")
+	if code != ExitOK || !strings.Contains(stdout, `"controls": []`) {
+		t.Errorf("Expected an empty controls array, got exit %d:\n%s", code, stdout)
+	}
+
+	code, stdout, _ = run(t, client, "search", "--output", "ndjson", "vulnerability")
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if code != ExitOK || len(lines) != 2 {
+		t.Fatalf("Expected 2 ndjson records, got exit %d:\n%s", code, stdout)
+	}
+	var rec struct {
+		SchemaVersion int    `json:"schema_version"`
+		Kind          string `json:"kind"`
+		Item          match  `json:"item"`
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &rec); err != nil || rec.SchemaVersion != SchemaVersion || rec.Item.Kind != "definition" {
+		t.Errorf("Expected a definition match record, got %+v (%v)", rec, err)
+	}
+
+	code, stdout, _ = run(t, client, "validate", "--doc", "FRD", "--output", "json")
+	if code != ExitOK || !strings.Contains(stdout, `"valid": true`) {
+		t.Errorf("Expected a valid validation result, got exit %d:\n%s", code, stdout)
+	}
+
+	if code, _, _ := run(t, client, "definitions", "--output", "xml"); code != ExitUsage {
+		t.Errorf("Expected exit %d for an unknown format, got %d", ExitUsage, code)
+	}
+}
+
+func TestExport(t *testing.T) {
+	client := newTestClient(t, queryFiles)
+
+	code, stdout, _ := run(t, client, "export", "--doc", "VDR", "--keyword", "SHOULD")
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if code != ExitOK || len(lines) != 2 || !strings.HasPrefix(lines[1], "FRR-VDR-02,VDR,") || !strings.HasSuffix(lines[1], ","+client.SourceLabel()) {
+		t.Errorf("Expected FRR-VDR-02 as CSV with its source, got exit %d:\n%s", code, stdout)
+	}
+
+	out := filepath.Join(t.TempDir(), "ksi.xlsx")
+	code, _, stderr := run(t, client, "export", "--kind", "indicators", "--per-control", "--out", out)
+	if code != ExitOK || !strings.Contains(stderr, "Exported 2 rows") {
+		t.Errorf("Expected a row per control mapping, got exit %d: %s", code, stderr)
+	}
+	if data, err := os.ReadFile(out); err != nil || !bytes.HasPrefix(data, []byte("PK")) {
+		t.Errorf("Expected an XLSX workbook at %s (%v)", out, err)
+	}
+
+	if code, stdout, _ := run(t, client, "export", "--format", "xlsx"); code != ExitUsage || stdout != "" {
+		t.Errorf("Expected exit %d and nothing on stdout for xlsx without --out, got %d", ExitUsage, code)
+	}
+	if code, _, _ := run(t, client, "export", "--format", "pdf"); code != ExitUsage {
+		t.Errorf("Expected exit %d for an unknown format, got %d", ExitUsage, code)
+	}
+}
+
+func TestExportDocument(t *testing.T) {
+	client := newTestClient(t, queryFiles)
+
+	code, stdout, _ := run(t, client, "export", "--format", "md", "--doc", "vdr")
+	if code != ExitOK || !strings.Contains(stdout, "#### FRR-VDR-01: Detect") || !strings.Contains(stdout, "> Source: "+client.SourceLabel()) {
+		t.Errorf("Expected VDR as Markdown with its source, got exit %d:\n%s", code, stdout)
+	}
+
+	// The format follows the output file's extension
+	out := filepath.Join(t.TempDir(), "vdr.html")
+	code, _, stderr := run(t, client, "export", "--doc", "VDR", "--out", out)
+	if code != ExitOK || !strings.Contains(stderr, "Exported VDR") {
+		t.Errorf("Expected VDR to be exported, got exit %d: %s", code, stderr)
+	}
+	if data, err := os.ReadFile(out); err != nil || !strings.Contains(string(data), `<span class="badge must">MUST</span>`) {
+		t.Errorf("Expected an HTML document at %s (%v)", out, err)
+	}
+
+	if code, _, _ := run(t, client, "export", "--format", "html"); code != ExitUsage {
+		t.Errorf("Expected exit %d without --doc, got %d", ExitUsage, code)
+	}
+	if code, _, stderr := run(t, client, "export", "--format", "md", "--doc", "XYZ"); code != ExitNotFound || !strings.Contains(stderr, "unknown document XYZ") || strings.Contains(stderr, "fetch failed") {
+		t.Errorf("Expected exit %d for an unknown document, got %d: %s", ExitNotFound, code, stderr)
+	}
+}
+
+func TestSite(t *testing.T) {
+	client := newTestClient(t, queryFiles)
+
+	out := filepath.Join(t.TempDir(), "public")
+	code, _, stderr := run(t, client, "site", "--out", out)
+	if code != ExitOK || !strings.Contains(stderr, "Wrote 9 pages") {
+		t.Errorf("Expected an index, 3 documents and 5 items, got exit %d: %s", code, stderr)
+	}
+	data, err := os.ReadFile(filepath.Join(out, "requirements", "FRR-VDR-01.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "Source: "+client.SourceLabel()) {
+		t.Errorf("Expected the source on every page, got:\n%s", data)
+	}
+	for _, name := range []string{"index.html", "search.js", "search-index.js", "documents/KSI.html", "indicators/KSI-CED-01.html", "definitions/FRD-ALL-01.html"} {
+		if _, err := os.Stat(filepath.Join(out, name)); err != nil {
+			t.Errorf("Expected %s: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/export"
+	"github.com/ethanolivertroy/fedramp-tui/internal/store"
+)
+
+// runExport writes requirements, definitions or indicators to a CSV or XLSX
+// spreadsheet, filtered like "list", or a whole document to Markdown or HTML
+func runExport(e *env, args []string) int {
+	fs := e.newFlagSet("export")
+	format := fs.String("format", "", "File format: csv, xlsx, md or html (default: from the --out extension, or csv)")
+	out := fs.String("out", "", "File to write (default: stdout; required for xlsx)")
+	kind := fs.String("kind", "requirements", "What to export: requirements, definitions or indicators")
+	doc := fs.String("doc", "", "Only export requirements of this document, e.g. VDR; the document to export as md or html")
+	keyword := fs.String("keyword", "", "Only export requirements with this primary keyword, e.g. MUST")
+	affects := fs.String("affects", "", "Only export requirements affecting this party")
+	theme := fs.String("theme", "", "Only export indicators of this theme, by code or name")
+	perControl := fs.Bool("per-control", false, "Export a row per indicator control mapping")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+
+	if *format == "" {
+		switch ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(*out)), "."); ext {
+		case export.XLSX, export.Markdown, export.HTML:
+			*format = ext
+		case "htm":
+			*format = export.HTML
+		default:
+			*format = export.CSV
+		}
+	}
+	switch *format {
+	case export.CSV:
+	case export.XLSX:
+		// A zip archive on stdout is useless in a terminal and easy to misuse in a pipe
+		if *out == "" {
+			fmt.Fprintln(e.stderr, "Error: --format xlsx needs --out, e.g. --out requirements.xlsx")
+			return ExitUsage
+		}
+	case export.Markdown, export.HTML:
+		return exportDocument(e, *format, *doc, *out)
+	default:
+		fmt.Fprintf(e.stderr, "Error: unknown --format %q; expected csv, xlsx, md or html\n", *format)
+		return ExitUsage
+	}
+
+	var codes []string
+	switch *kind {
+	case "requirements":
+		if *doc != "" {
+			codes = []string{strings.ToUpper(*doc)}
+		}
+	case "indicators":
+		codes = []string{"KSI"}
+	case "definitions":
+		codes = []string{"FRD"}
+	default:
+		fmt.Fprintf(e.stderr, "Error: cannot export %q; expected requirements, definitions or indicators\n", *kind)
+		return ExitUsage
+	}
+
+	s, status := e.loadStore(codes)
+	if status != ExitOK {
+		return status
+	}
+
+	source := e.client.SourceLabel()
+	var table export.Table
+	switch *kind {
+	case "requirements":
+		table = export.Requirements(s.FilterRequirements(store.Filter{Document: *doc, Keyword: *keyword, Affects: *affects}), source)
+	case "indicators":
+		table = export.Indicators(filterIndicators(s.Indicators(), *theme), *perControl, source)
+	case "definitions":
+		table = export.Definitions(s.Definitions(), source)
+	}
+
+	if err := writeFile(e.stdout, *out, func(w io.Writer) error { return export.Write(w, *format, table) }); err != nil {
+		fmt.Fprintf(e.stderr, "Error: %v\n", err)
+		return ExitFailure
+	}
+	if *out != "" {
+		fmt.Fprintf(e.stderr, "Exported %d rows to %s\n", len(table.Rows), *out)
+	}
+	return found(len(table.Rows))
+}
+
+// exportDocument writes a whole document as Markdown or HTML
+func exportDocument(e *env, format, code, out string) int {
+	if code == "" {
+		fmt.Fprintf(e.stderr, "Error: --format %s needs --doc, e.g. --doc VDR\n", format)
+		return ExitUsage
+	}
+	code = strings.ToUpper(code)
+
+	s, status := e.loadStore([]string{code})
+	if status != ExitOK {
+		return status
+	}
+	d, ok := export.NewDocument(s, code, e.client.SourceLabel())
+	if !ok {
+		fmt.Fprintf(e.stderr, "Error: document %s not found\n", code)
+		return ExitNotFound
+	}
+
+	if err := writeFile(e.stdout, out, func(w io.Writer) error { return export.WriteDocument(w, format, d) }); err != nil {
+		fmt.Fprintf(e.stderr, "Error: %v\n", err)
+		return ExitFailure
+	}
+	if out != "" {
+		fmt.Fprintf(e.stderr, "Exported %s to %s\n", code, out)
+	}
+	return ExitOK
+}
+
+// writeFile calls write with the named file, or with stdout if name is empty
+func writeFile(stdout io.Writer, name string, write func(io.Writer) error) error {
+	if name == "" {
+		return write(stdout)
+	}
+	return export.WriteFile(name, write)
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// SchemaVersion is the version of the structured output. It changes only when
+// a field is renamed or removed or its type changes; fields may be added
+// without a new version.
+const SchemaVersion = 1
+
+// Output formats for the query commands
+const (
+	formatTable  = "table"
+	formatJSON   = "json"
+	formatYAML   = "yaml"
+	formatNDJSON = "ndjson"
+)
+
+var formats = []string{formatTable, formatJSON, formatYAML, formatNDJSON}
+
+// envelope wraps the items of a json or yaml result
+type envelope struct {
+	SchemaVersion int    `json:"schema_version"`
+	Kind          string `json:"kind"`   // Type of the items, e.g. "requirement"
+	Source        string `json:"source"` // Where the documents were loaded from
+	Count         int    `json:"count"`
+	Items         any    `json:"items"`
+}
+
+// record is one line of an ndjson result
+type record struct {
+	SchemaVersion int    `json:"schema_version"`
+	Kind          string `json:"kind"`
+	Source        string `json:"source"`
+	Item          any    `json:"item"`
+}
+
+// outputFlag adds the --output flag to a command
+func outputFlag(fs *flag.FlagSet) *string {
+	return fs.String("output", formatTable, "Output format: "+strings.Join(formats, ", "))
+}
+
+// checkFormat reports an unknown output format, returning false if the
+// command should exit with ExitUsage
+func (e *env) checkFormat(format string) bool {
+	for _, f := range formats {
+		if format == f {
+			return true
+		}
+	}
+	fmt.Fprintf(e.stderr, "Error: unknown --output %q; expected one of %s\n", format, strings.Join(formats, ", "))
+	return false
+}
+
+// writeItems writes items as json, yaml or ndjson and returns the exit code:
+// ExitNotFound if there are none
+func writeItems[T any](e *env, format, kind string, items []T) int {
+	if items == nil {
+		items = []T{}
+	}
+	source := e.client.SourceLabel()
+
+	var err error
+	switch format {
+	case formatJSON:
+		enc := json.NewEncoder(e.stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(envelope{SchemaVersion, kind, source, len(items), items})
+	case formatYAML:
+		err = writeYAML(e.stdout, envelope{SchemaVersion, kind, source, len(items), items})
+	case formatNDJSON:
+		enc := json.NewEncoder(e.stdout)
+		for _, item := range items {
+			if err = enc.Encode(record{SchemaVersion, kind, source, item}); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(e.stderr, "Error: writing %s: %v\n", format, err)
+		return ExitFailure
+	}
+	return found(len(items))
+}
+
+// plural formats a count with its noun, e.g. "1 issue" or "2 issues"
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
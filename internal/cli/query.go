@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+	"github.com/ethanolivertroy/fedramp-tui/internal/store"
+)
+
+// Widths of free-text table columns
+const (
+	nameWidth      = 60
+	statementWidth = 80
+)
+
+// listKinds are the item types that "list" accepts
+var listKinds = []string{"documents", "requirements", "definitions", "indicators"}
+
+// runList prints a table of documents, requirements, definitions or indicators
+func runList(e *env, args []string) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		fmt.Fprintf(e.stderr, "Usage: fedramp list {%s} [flags]\n", strings.Join(listKinds, "|"))
+		return ExitUsage
+	}
+	switch args[0] {
+	case "documents":
+		return runDocuments(e, args[1:])
+	case "requirements":
+		return runRequirements(e, args[1:])
+	case "definitions":
+		return runDefinitions(e, args[1:])
+	case "indicators":
+		return runIndicators(e, args[1:])
+	}
+	fmt.Fprintf(e.stderr, "Error: cannot list %q; expected one of %s\n", args[0], strings.Join(listKinds, ", "))
+	return ExitUsage
+}
+
+// runDocuments lists the FRMR documents with their item counts
+func runDocuments(e *env, args []string) int {
+	fs := e.newFlagSet("list documents")
+	format := outputFlag(fs)
+	if err := fs.Parse(args); err != nil || !e.checkFormat(*format) {
+		return ExitUsage
+	}
+
+	s, status := e.loadStore(nil)
+	if status != ExitOK {
+		return status
+	}
+	if *format != formatTable {
+		return writeItems(e, *format, "document", s.Documents())
+	}
+
+	tw := newTable(e.stdout, "CODE", "NAME", "REQUIREMENTS", "INDICATORS", "DEFINITIONS", "STATUS")
+	for _, d := range s.Documents() {
+		status := "current"
+		if d.Stale {
+			status = "stale"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%s\n", d.Code, d.Name, d.RequirementCount, d.IndicatorCount, d.DefinitionCount, status)
+	}
+	_ = tw.Flush()
+	return found(len(s.Documents()))
+}
+
+// runRequirements lists requirements matching the document, keyword and
+// affected party filters
+func runRequirements(e *env, args []string) int {
+	fs := e.newFlagSet("list requirements")
+	doc := fs.String("doc", "", "Only list requirements of this document, e.g. VDR")
+	keyword := fs.String("keyword", "", "Only list requirements with this primary keyword, e.g. MUST")
+	affects := fs.String("affects", "", "Only list requirements affecting this party: Providers, Agencies, Assessors or FedRAMP")
+	format := outputFlag(fs)
+	if err := fs.Parse(args); err != nil || !e.checkFormat(*format) {
+		return ExitUsage
+	}
+
+	var codes []string
+	if *doc != "" {
+		codes = []string{strings.ToUpper(*doc)}
+	}
+	s, status := e.loadStore(codes)
+	if status != ExitOK {
+		return status
+	}
+
+	requirements := s.FilterRequirements(store.Filter{Document: *doc, Keyword: *keyword, Affects: *affects})
+	if *format != formatTable {
+		return writeItems(e, *format, "requirement", requirements)
+	}
+	tw := newTable(e.stdout, "ID", "DOCUMENT", "KEYWORD", "IMPACT", "AFFECTS", "NAME")
+	for _, r := range requirements {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.ID, r.DocumentCode, orDash(r.PrimaryKeyWord), r.Impact, orDash(strings.Join(r.Affects, ", ")), truncate(requirementName(r), nameWidth))
+	}
+	_ = tw.Flush()
+	return found(len(requirements))
+}
+
+// runDefinitions lists FedRAMP definitions with how many items use each term
+func runDefinitions(e *env, args []string) int {
+	fs := e.newFlagSet("definitions")
+	format := outputFlag(fs)
+	if err := fs.Parse(args); err != nil || !e.checkFormat(*format) {
+		return ExitUsage
+	}
+
+	s, status := e.loadStore(nil)
+	if status != ExitOK {
+		return status
+	}
+	if *format != formatTable {
+		return writeItems(e, *format, "definition", s.Definitions())
+	}
+
+	tw := newTable(e.stdout, "ID", "TERM", "USED IN", "DEFINITION")
+	for _, d := range s.Definitions() {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", d.ID, d.Term, len(s.Usages(d.ID)), truncate(d.Text, statementWidth))
+	}
+	_ = tw.Flush()
+	return found(len(s.Definitions()))
+}
+
+// runIndicators lists Key Security Indicators, optionally of a single theme
+func runIndicators(e *env, args []string) int {
+	fs := e.newFlagSet("indicators")
+	theme := fs.String("theme", "", "Only list indicators of this theme, by code or name, e.g. IAM")
+	format := outputFlag(fs)
+	if err := fs.Parse(args); err != nil || !e.checkFormat(*format) {
+		return ExitUsage
+	}
+
+	s, status := e.loadStore([]string{"KSI"})
+	if status != ExitOK {
+		return status
+	}
+
+	indicators := filterIndicators(s.Indicators(), *theme)
+	if *format != formatTable {
+		return writeItems(e, *format, "indicator", indicators)
+	}
+	tw := newTable(e.stdout, "ID", "THEME", "IMPACT", "CONTROLS", "NAME")
+	for _, ind := range indicators {
+		name := ind.Name
+		if ind.Retired {
+			name += " (retired)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", ind.ID, ind.ThemeCode, ind.Impact, len(ind.Controls), truncate(name, nameWidth))
+	}
+	_ = tw.Flush()
+	return found(len(indicators))
+}
+
+// filterIndicators returns the indicators of a theme, matched by code or
+// name ignoring case, or all of them if theme is empty
+func filterIndicators(indicators []model.Indicator, theme string) []model.Indicator {
+	if theme == "" {
+		return indicators
+	}
+	var out []model.Indicator
+	for _, ind := range indicators {
+		if strings.EqualFold(ind.ThemeCode, theme) || strings.EqualFold(ind.ThemeName, theme) {
+			out = append(out, ind)
+		}
+	}
+	return out
+}
+
+// newTable returns a tab-aligned writer with the header row written
+func newTable(w io.Writer, columns ...string) *tabwriter.Writer {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(columns, "\t"))
+	return tw
+}
+
+// found returns ExitNotFound if nothing matched
+func found(n int) int {
+	if n == 0 {
+		return ExitNotFound
+	}
+	return ExitOK
+}
+
+// requirementName returns the requirement's name, or its statement if it has none
+func requirementName(r model.Requirement) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.Statement
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// truncate shortens s to max runes on a single line, so it fits a table cell
+func truncate(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	return string([]rune(s)[:max-3]) + "..."
+}
@@ -0,0 +1,288 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/catalog"
+	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+	"github.com/ethanolivertroy/fedramp-tui/internal/store"
+)
+
+// runShow prints everything known about a requirement, indicator,
+// definition, document or SP 800-53 control. It exits with ExitNotFound if
+// nothing has the given ID.
+func runShow(e *env, args []string) int {
+	fs := e.newFlagSet("show")
+	format := outputFlag(fs)
+	args, err := parseArgs(fs, args)
+	if err != nil || !e.checkFormat(*format) {
+		return ExitUsage
+	}
+	if len(args) != 1 {
+		fmt.Fprintln(e.stderr, "Usage: fedramp show [--output FORMAT] <ID>")
+		return ExitUsage
+	}
+	id := strings.TrimSpace(args[0])
+
+	s, status := e.loadStore(nil)
+	if status != ExitOK {
+		return status
+	}
+
+	structured := *format != formatTable
+	if r, ok := s.Requirement(id); ok {
+		if structured {
+			return writeItems(e, *format, "requirement", []model.Requirement{r})
+		}
+		showRequirement(e.stdout, r)
+		return ExitOK
+	}
+	if ind, ok := s.Indicator(id); ok {
+		if structured {
+			return writeItems(e, *format, "indicator", []model.Indicator{ind})
+		}
+		showIndicator(e.stdout, ind)
+		return ExitOK
+	}
+	if d, ok := s.Definition(id); ok {
+		if structured {
+			return writeItems(e, *format, "definition", []model.Definition{d})
+		}
+		showDefinition(e.stdout, s, d)
+		return ExitOK
+	}
+	if d, ok := s.Document(strings.ToUpper(id)); ok {
+		if structured {
+			return writeItems(e, *format, "document", []model.Document{d})
+		}
+		showDocument(e.stdout, s, d)
+		return ExitOK
+	}
+	if c, ok := s.MappedControl(id); ok {
+		if structured {
+			return writeItems(e, *format, "control", []store.MappedControl{c})
+		}
+		showControl(e.stdout, s, c)
+		return ExitOK
+	}
+
+	fmt.Fprintf(e.stderr, "Error: %s not found\n", id)
+	return ExitNotFound
+}
+
+// fields writes aligned "Label: value" lines, skipping empty values
+type fields struct {
+	tw *tabwriter.Writer
+}
+
+func newFields(w io.Writer) fields {
+	return fields{tw: tabwriter.NewWriter(w, 0, 4, 1, ' ', 0)}
+}
+
+func (f fields) add(label, value string) {
+	if value != "" {
+		fmt.Fprintf(f.tw, "%s:\t%s\n", label, value)
+	}
+}
+
+func (f fields) flush() {
+	_ = f.tw.Flush()
+}
+
+// section writes a heading followed by indented lines
+func section(w io.Writer, heading string, lines ...string) {
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n%s:\n", heading)
+	for _, line := range lines {
+		fmt.Fprintf(w, "  %s\n", line)
+	}
+}
+
+func showRequirement(w io.Writer, r model.Requirement) {
+	f := newFields(w)
+	f.add("ID", r.ID)
+	f.add("Name", r.Name)
+	f.add("Document", r.DocumentCode)
+	f.add("Category", r.Category.Label())
+	f.add("Keyword", r.PrimaryKeyWord)
+	f.add("Impact", r.Impact.String())
+	f.add("Affects", strings.Join(r.Affects, ", "))
+	f.add("Part of", r.ParentID)
+	f.flush()
+
+	section(w, "Statement", r.Statement)
+	section(w, "Following", r.Following...)
+	section(w, "Sub-requirements", r.Children...)
+	if r.Note != "" {
+		section(w, "Note", r.Note)
+	}
+}
+
+func showIndicator(w io.Writer, ind model.Indicator) {
+	f := newFields(w)
+	f.add("ID", ind.ID)
+	f.add("Name", ind.Name)
+	f.add("Theme", strings.TrimSpace(ind.ThemeCode+" "+ind.ThemeName))
+	f.add("Impact", ind.Impact.String())
+	if ind.Retired {
+		f.add("Status", "retired")
+	}
+	f.add("Reference", ind.Reference)
+	f.add("Reference URL", ind.ReferenceURL)
+	f.flush()
+
+	section(w, "Statement", ind.Statement)
+	var controls []string
+	for _, c := range ind.Controls {
+		controls = append(controls, fmt.Sprintf("%s %s", catalog.LabelFor(c.ControlID), c.Title))
+	}
+	section(w, "Controls", controls...)
+	if ind.Note != "" {
+		section(w, "Note", ind.Note)
+	}
+}
+
+func showDefinition(w io.Writer, s *store.Store, d model.Definition) {
+	f := newFields(w)
+	f.add("ID", d.ID)
+	f.add("Term", d.Term)
+	f.add("Alternatives", strings.Join(d.Alts, ", "))
+	f.add("Reference", d.Reference)
+	f.add("Reference URL", d.ReferenceURL)
+	f.flush()
+
+	section(w, "Definition", d.Text)
+	if d.Note != "" {
+		section(w, "Note", d.Note)
+	}
+	var usages []string
+	for _, u := range s.Usages(d.ID) {
+		usages = append(usages, u.ID)
+	}
+	section(w, "Used in", usages...)
+}
+
+func showDocument(w io.Writer, s *store.Store, d model.Document) {
+	f := newFields(w)
+	f.add("Code", d.Code)
+	f.add("Name", d.Name)
+	f.add("Description", d.Description)
+	f.add("Requirements", fmt.Sprint(len(s.DocumentRequirements(d.Code))))
+	if !d.FetchedAt.IsZero() {
+		f.add("Fetched", d.FetchedAt.Format("2006-01-02 15:04 MST"))
+	}
+	f.flush()
+
+	if d.Purpose != "" {
+		section(w, "Purpose", d.Purpose)
+	}
+	section(w, "Expected outcomes", d.ExpectedOutcomes...)
+	section(w, "Warnings", d.Warnings()...)
+	var releases []string
+	for _, r := range d.Releases {
+		releases = append(releases, strings.TrimSpace(fmt.Sprintf("%s  %s  %s", r.ID, r.PublishedDate, r.Description)))
+	}
+	section(w, "Releases", releases...)
+	var categories []string
+	for _, c := range s.Categories(d.Code) {
+		categories = append(categories, c.Label())
+	}
+	section(w, "Categories", categories...)
+}
+
+func showControl(w io.Writer, s *store.Store, c store.MappedControl) {
+	family := catalog.Family(c.ID)
+	f := newFields(w)
+	f.add("Control", c.ID)
+	f.add("Title", c.Title)
+	f.add("Family", strings.TrimSpace(family+" "+catalog.Families[family]))
+	f.flush()
+
+	var indicators []string
+	for _, ind := range s.IndicatorsForControl(c.ID) {
+		indicators = append(indicators, ind.ID+" "+ind.Name)
+	}
+	section(w, "Mapped KSIs", indicators...)
+}
+
+// match is an item found by search
+type match struct {
+	Kind  string `json:"kind"` // e.g. "requirement"
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Field string `json:"field"` // Where the query was found
+}
+
+// runSearch finds requirements, indicators and definitions whose text
+// contains the query, ignoring case. It exits with ExitNotFound if nothing
+// matches.
+func runSearch(e *env, args []string) int {
+	fs := e.newFlagSet("search")
+	format := outputFlag(fs)
+	args, err := parseArgs(fs, args)
+	if err != nil || !e.checkFormat(*format) {
+		return ExitUsage
+	}
+	query := strings.TrimSpace(strings.Join(args, " "))
+	if query == "" {
+		fmt.Fprintln(e.stderr, "Usage: fedramp search [--output FORMAT] <query>")
+		return ExitUsage
+	}
+
+	s, status := e.loadStore(nil)
+	if status != ExitOK {
+		return status
+	}
+
+	matches := search(s, query)
+	if *format != formatTable {
+		return writeItems(e, *format, "match", matches)
+	}
+	tw := newTable(e.stdout, "TYPE", "ID", "MATCHED", "TITLE")
+	for _, m := range matches {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", m.Kind, m.ID, m.Field, truncate(m.Title, nameWidth))
+	}
+	_ = tw.Flush()
+	if len(matches) == 0 {
+		fmt.Fprintf(e.stderr, "No matches for %q\n", query)
+	}
+	return found(len(matches))
+}
+
+// search returns the items containing query, in document order: requirements,
+// then indicators, then definitions. Each item is listed once, for the first
+// field that matches.
+func search(s *store.Store, query string) []match {
+	query = strings.ToLower(query)
+	first := func(fields ...string) string {
+		for i := 0; i+1 < len(fields); i += 2 {
+			if strings.Contains(strings.ToLower(fields[i+1]), query) {
+				return fields[i]
+			}
+		}
+		return ""
+	}
+
+	var matches []match
+	for _, r := range s.Requirements() {
+		if field := first("id", r.ID, "name", r.Name, "statement", r.Statement, "following", strings.Join(r.Following, "\n"), "note", r.Note); field != "" {
+			matches = append(matches, match{store.RefRequirement.String(), r.ID, requirementName(r), field})
+		}
+	}
+	for _, ind := range s.Indicators() {
+		if field := first("id", ind.ID, "name", ind.Name, "statement", ind.Statement, "note", ind.Note); field != "" {
+			matches = append(matches, match{store.RefIndicator.String(), ind.ID, ind.Name, field})
+		}
+	}
+	for _, d := range s.Definitions() {
+		if field := first("id", d.ID, "term", d.Term, "alternatives", strings.Join(d.Alts, "\n"), "definition", d.Text, "note", d.Note); field != "" {
+			matches = append(matches, match{store.RefDefinition.String(), d.ID, d.Term, field})
+		}
+	}
+	return matches
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/site"
+)
+
+// runSite generates a static HTML site of every document, for readers
+// without a terminal
+func runSite(e *env, args []string) int {
+	fs := e.newFlagSet("site")
+	out := fs.String("out", "public", "Directory to write the site to")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+
+	s, status := e.loadStore(nil)
+	if status != ExitOK {
+		return status
+	}
+	n, err := site.New(s, e.client.SourceLabel()).Write(*out)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "Error: %v\n", err)
+		return ExitFailure
+	}
+	fmt.Fprintf(e.stderr, "Wrote %d pages to %s\n", n, *out)
+	return ExitOK
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/api"
+	"github.com/ethanolivertroy/fedramp-tui/internal/schema"
+)
+
+// validation is the structured result of validating one document
+type validation struct {
+	Document   string             `json:"document"`
+	Valid      bool               `json:"valid"`
+	Schema     string             `json:"schema,omitempty"`
+	Error      string             `json:"error,omitempty"` // Why the document could not be fetched
+	Violations []schema.Violation `json:"violations"`
+}
+
+func newValidation(res api.DocumentResult) validation {
+	v := validation{Document: res.Code, Violations: res.Validation.Violations}
+	if res.Error != nil {
+		v.Error = res.Error.Error()
+		return v
+	}
+	v.Valid = res.Validation.Valid()
+	v.Schema = res.Validation.Schema
+	if v.Violations == nil {
+		v.Violations = []schema.Violation{}
+	}
+	return v
+}
+
+// runValidate checks documents against their JSON Schema and prints every
+// violation. It exits with ExitFailure if any document is invalid or could
+// not be loaded.
+func runValidate(e *env, args []string) int {
+	fs := e.newFlagSet("validate")
+	docs := fs.String("doc", "", "Comma-separated document codes to validate (default: all)")
+	format := outputFlag(fs)
+	if err := fs.Parse(args); err != nil || !e.checkFormat(*format) {
+		return ExitUsage
+	}
+
+	var codes []string
+	if *docs != "" {
+		for _, code := range strings.Split(*docs, ",") {
+			codes = append(codes, strings.ToUpper(strings.TrimSpace(code)))
+		}
+	}
+
+	results, err := e.loadDocuments(codes)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "Error: %v\n", err)
+		return ExitNotFound
+	}
+
+	if *format != formatTable {
+		validations := make([]validation, len(results))
+		failed := false
+		for i, res := range results {
+			validations[i] = newValidation(res)
+			failed = failed || !validations[i].Valid
+		}
+		if code := writeItems(e, *format, "validation", validations); code == ExitFailure || !failed {
+			return code
+		}
+		return ExitFailure
+	}
+
+	tw := tabwriter.NewWriter(e.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "DOCUMENT\tSTATUS\tSCHEMA")
+	issues, failed := 0, 0
+	for _, res := range results {
+		v := res.Validation
+		switch {
+		case res.Error != nil:
+			failed++
+			fmt.Fprintf(tw, "%s\tfetch failed: %v\t-\n", res.Code, res.Error)
+			continue
+		case v.Valid():
+			fmt.Fprintf(tw, "%s\tvalid\t%s\n", res.Code, v.Schema)
+		default:
+			failed++
+			issues += len(v.Violations)
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", res.Code, plural(len(v.Violations), "issue"), v.Schema)
+		}
+	}
+	_ = tw.Flush()
+
+	for _, res := range results {
+		v := res.Validation
+		if v.SchemaError != nil {
+			fmt.Fprintf(e.stderr, "warning: %s: declared schema unavailable, used bundled schema: %v\n", res.Code, v.SchemaError)
+		}
+		if len(v.Violations) == 0 {
+			continue
+		}
+		fmt.Fprintf(e.stdout, "\n%s:\n", res.Code)
+		for _, violation := range v.Violations {
+			fmt.Fprintf(e.stdout, "  %s\n", violation)
+		}
+	}
+
+	if failed > 0 {
+		fmt.Fprintf(e.stdout, "\n%s; %d of %s failed validation\n", plural(issues, "schema issue"), failed, plural(len(results), "document"))
+		return ExitFailure
+	}
+	return ExitOK
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// node is a decoded JSON value that keeps the order of object keys
+type node struct {
+	keys   []string // Object keys, in order
+	values []node   // Object values or array elements
+	array  bool
+	object bool
+	scalar string // JSON text of a string, number, boolean or null
+}
+
+// writeYAML writes v as YAML. Values are marshaled as JSON first, so field
+// names, omitempty and field order follow the JSON output exactly.
+func writeYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	n, err := decodeNode(dec)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	if n.object || n.array {
+		writeYAMLNode(&b, n, 0)
+	} else {
+		b.WriteString(n.scalar + "\n")
+	}
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// decodeNode reads the next JSON value from dec
+func decodeNode(dec *json.Decoder) (node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return node{}, err
+	}
+	switch tok := tok.(type) {
+	case json.Delim:
+		n := node{object: tok == '{', array: tok == '['}
+		for dec.More() {
+			if n.object {
+				key, err := dec.Token()
+				if err != nil {
+					return node{}, err
+				}
+				n.keys = append(n.keys, key.(string))
+			}
+			value, err := decodeNode(dec)
+			if err != nil {
+				return node{}, err
+			}
+			n.values = append(n.values, value)
+		}
+		if _, err := dec.Token(); err != nil { // Closing delimiter
+			return node{}, err
+		}
+		return n, nil
+	case string:
+		// JSON strings are valid YAML double-quoted scalars
+		quoted, _ := json.Marshal(tok)
+		return node{scalar: string(quoted)}, nil
+	case nil:
+		return node{scalar: "null"}, nil
+	default:
+		return node{scalar: fmt.Sprint(tok)}, nil
+	}
+}
+
+// inline returns a value that fits on the line of its key or dash: a scalar
+// or an empty collection
+func (n node) inline() (string, bool) {
+	switch {
+	case n.object && len(n.values) == 0:
+		return "{}", true
+	case n.array && len(n.values) == 0:
+		return "[]", true
+	case !n.object && !n.array:
+		return n.scalar, true
+	}
+	return "", false
+}
+
+// plainKey matches object keys that need no quoting
+var plainKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+func yamlKey(key string) string {
+	if plainKey.MatchString(key) {
+		return key
+	}
+	quoted, _ := json.Marshal(key)
+	return string(quoted)
+}
+
+// writeYAMLNode writes a non-empty object or array at the given indent
+func writeYAMLNode(b *strings.Builder, n node, indent int) {
+	pad := strings.Repeat(" ", indent)
+	for i, value := range n.values {
+		// The first line of an entry starts with its key or a dash
+		if n.object {
+			b.WriteString(pad + yamlKey(n.keys[i]) + ":")
+		} else {
+			b.WriteString(pad + "-")
+		}
+		if s, ok := value.inline(); ok {
+			b.WriteString(" " + s + "\n")
+			continue
+		}
+		if !n.object && value.object {
+			// Start a mapping on the dash line: "- key: value"
+			var nested strings.Builder
+			writeYAMLNode(&nested, value, indent+2)
+			b.WriteString(" " + strings.TrimPrefix(nested.String(), pad+"  "))
+			continue
+		}
+		b.WriteString("\n")
+		writeYAMLNode(b, value, indent+2)
+	}
+}
@@ -0,0 +1,56 @@
+// Package config reads persistent settings from ~/.config/fedramp-tui/config.json
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Config holds settings that apply to every run. Command line flags take
+// precedence over them.
+type Config struct {
+	// Ref pins documents to a FedRAMP/docs branch, tag or commit SHA
+	Ref string `json:"ref,omitempty"`
+	// Source loads documents from a URL, directory or .tar.gz archive
+	Source string `json:"source,omitempty"`
+	// Catalog is an OSCAL SP 800-53 catalog JSON file for control details
+	Catalog string `json:"catalog,omitempty"`
+}
+
+// Path returns the default config file location
+func Path() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".config", "fedramp-tui", "config.json"), nil
+}
+
+// Load reads the config file at the default location. A missing file yields
+// an empty config.
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return &Config{}, nil
+	}
+	return LoadFile(path)
+}
+
+// LoadFile reads a config file. A missing file yields an empty config.
+func LoadFile(path string) (*Config, error) {
+	cfg := &Config{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return cfg, nil
+}
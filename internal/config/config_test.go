@@ -0,0 +1,35 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg, err := LoadFile(filepath.Join(dir, "missing.json"))
+	if err != nil || *cfg != (Config{}) {
+		t.Errorf("Expected empty config for missing file, got %+v, %v", cfg, err)
+	}
+
+	path := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(path, []byte(`{"ref": "v1.2.0"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Ref != "v1.2.0" || cfg.Source != "" {
+		t.Errorf("Unexpected config %+v", cfg)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"ref":`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFile(path); err == nil {
+		t.Error("Expected error for malformed config")
+	}
+}
@@ -0,0 +1,105 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+	"github.com/ethanolivertroy/fedramp-tui/internal/store"
+)
+
+// Document formats
+const (
+	Markdown = "md"
+	HTML     = "html"
+)
+
+// Document is a complete FRMR document with everything it contains
+type Document struct {
+	model.Document
+	Requirements []model.Requirement
+	Indicators   []model.Indicator  // KSI only
+	Definitions  []model.Definition // FRD only
+	Source       string             // Where the document was loaded from, e.g. "FedRAMP/docs@main"
+}
+
+// NewDocument collects a document and its contents from the store
+func NewDocument(s *store.Store, code, source string) (Document, bool) {
+	doc, ok := s.Document(strings.ToUpper(code))
+	if !ok {
+		return Document{}, false
+	}
+	d := Document{Document: doc, Requirements: s.DocumentRequirements(doc.Code), Source: source}
+	switch doc.Code {
+	case "KSI":
+		d.Indicators = s.Indicators()
+	case "FRD":
+		d.Definitions = s.Definitions()
+	}
+	return d, true
+}
+
+// CategoryGroup is a requirement category with its requirements
+type CategoryGroup struct {
+	model.Category
+	Requirements []model.Requirement
+}
+
+// Categories groups the requirements by category, in the order categories
+// first appear
+func (d Document) Categories() []CategoryGroup {
+	var groups []CategoryGroup
+	index := make(map[string]int)
+	for _, r := range d.Requirements {
+		i, ok := index[r.Category.ID]
+		if !ok {
+			i = len(groups)
+			index[r.Category.ID] = i
+			groups = append(groups, CategoryGroup{Category: r.Category})
+		}
+		groups[i].Requirements = append(groups[i].Requirements, r)
+	}
+	return groups
+}
+
+// ThemeGroup is a KSI theme with its indicators
+type ThemeGroup struct {
+	Code        string
+	Name        string
+	Description string
+	Indicators  []model.Indicator
+}
+
+// Themes groups the indicators by theme, in document order
+func (d Document) Themes() []ThemeGroup {
+	var groups []ThemeGroup
+	for _, ind := range d.Indicators {
+		if len(groups) == 0 || groups[len(groups)-1].Code != ind.ThemeCode {
+			groups = append(groups, ThemeGroup{Code: ind.ThemeCode, Name: ind.ThemeName, Description: ind.ThemeDesc})
+		}
+		g := &groups[len(groups)-1]
+		g.Indicators = append(g.Indicators, ind)
+	}
+	return groups
+}
+
+// Fetched returns when the document was fetched, for citing it
+func (d Document) Fetched() string {
+	if d.FetchedAt.IsZero() {
+		return ""
+	}
+	return d.FetchedAt.UTC().Format(time.DateOnly)
+}
+
+// WriteDocument writes the document as Markdown or HTML
+func WriteDocument(w io.Writer, format string, d Document) error {
+	switch format {
+	case Markdown:
+		return WriteMarkdown(w, d)
+	case HTML:
+		return WriteHTML(w, d)
+	}
+	return fmt.Errorf("unknown document format %q", format)
+}
@@ -0,0 +1,204 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+)
+
+var testIndicators = []model.Indicator{
+	{ID: "KSI-IAM-01", ThemeName: "Identity and Access Management", Name: "MFA", Controls: []model.Control{{ControlID: "ia-2.1", Title: "Multi-factor Authentication"}, {ControlID: "ac-2", Title: "Account Management"}}},
+	{ID: "KSI-CED-01", ThemeName: "Cybersecurity Education", Name: "Training", Retired: true},
+}
+
+func TestWriteCSV(t *testing.T) {
+	table := Requirements([]model.Requirement{
+		{ID: "FRR-VDR-01", DocumentCode: "VDR", Name: "Detect", Statement: "Providers MUST detect, \"triage\" and fix.", PrimaryKeyWord: "MUST", Impact: model.Impact{Low: true, High: true}, Affects: []string{"Providers", "Agencies"}},
+	}, "FedRAMP/docs@main")
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, table); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"FRR-VDR-01", "VDR", "", "Detect", "Providers MUST detect, \"triage\" and fix.", "MUST", "Low, High", "Providers, Agencies", "FedRAMP/docs@main"}
+	if len(rows) != 2 || rows[0][0] != "ID" || !slices.Equal(rows[1], want) {
+		t.Errorf("Expected header and %v, got %v", want, rows)
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.csv")
+	if err := WriteFile(path, func(w io.Writer) error { return WriteCSV(w, Indicators(testIndicators, false, "test")) }); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(path); err != nil || !bytes.HasPrefix(data, []byte("ID,")) {
+		t.Errorf("Expected CSV in %s, got %q (%v)", path, data, err)
+	}
+
+	failed := errors.New("disk full")
+	if err := WriteFile(path, func(io.Writer) error { return failed }); !errors.Is(err, failed) || !strings.Contains(err.Error(), path) {
+		t.Errorf("Expected the write error naming the file, got %v", err)
+	}
+}
+
+func TestIndicatorsPerControl(t *testing.T) {
+	table := Indicators(testIndicators, false, "src")
+	if len(table.Rows) != 2 || table.Rows[0][6] != "IA-2(1), AC-2" || table.Rows[1][5] != "retired" {
+		t.Errorf("Expected one row per indicator, got %v", table.Rows)
+	}
+
+	table = Indicators(testIndicators, true, "src")
+	var controls []string
+	for _, row := range table.Rows {
+		controls = append(controls, row[0]+" "+row[6])
+	}
+	if want := []string{"KSI-IAM-01 IA-2(1)", "KSI-IAM-01 AC-2", "KSI-CED-01 "}; !slices.Equal(controls, want) {
+		t.Errorf("Expected one row per control mapping %v, got %v", want, controls)
+	}
+	if row := table.Rows[1]; len(row) != len(table.Columns) || row[7] != "Account Management" || row[8] != "src" {
+		t.Errorf("Unexpected control row %v", row)
+	}
+}
+
+func TestWriteXLSX(t *testing.T) {
+	table := Definitions([]model.Definition{{ID: "FRD-ALL-01", Term: "Agency <federal>", Text: "A & B"}}, "src")
+	table.Name = "Definitions: [all]"
+
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, table); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(rc)
+		_ = rc.Close()
+		files[f.Name] = string(data)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("Expected workbook part %s", name)
+		}
+	}
+	sheet := files["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">ID</t></is></c>`,
+		`<c r="B2" t="inlineStr"><is><t xml:space="preserve">Agency &lt;federal&gt;</t></is></c>`,
+		`A &amp; B`,
+		`<autoFilter ref="A1:G2"/>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("Expected sheet to contain %s", want)
+		}
+	}
+	if !strings.Contains(files["xl/workbook.xml"], `name="Definitions- -all-"`) {
+		t.Errorf("Expected a sanitized sheet name, got %s", files["xl/workbook.xml"])
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := columnName(i); got != want {
+			t.Errorf("columnName(%d) = %q, want %q", i, got, want)
+		}
+	}
+}
+
+var testDocument = Document{
+	Document: model.Document{
+		Code:             "VDR",
+		Name:             "Vulnerability Detection and Response",
+		Purpose:          "Find and fix vulnerabilities.",
+		ExpectedOutcomes: []string{"Fewer vulnerabilities"},
+		Authority:        []model.Authority{{Reference: "OMB M-24-15", ReferenceURL: "https://example.gov/m-24-15"}},
+		Releases:         []model.Release{{ID: "25.09A", PublishedDate: "2025-09-10", Description: "Initial release"}},
+	},
+	Requirements: []model.Requirement{
+		{ID: "FRR-VDR-01", Category: model.Category{ID: "base", Name: "Base Requirements"}, Name: "Detect", Statement: "Providers MUST detect <all> vulnerabilities.", PrimaryKeyWord: "MUST", Impact: model.Impact{High: true}},
+		{ID: "FRR-VDR-AY-01", Category: model.Category{ID: "AY", Name: "Agencies"}, Name: "Review", PrimaryKeyWord: "SHOULD"},
+		{ID: "FRR-VDR-02", Category: model.Category{ID: "base", Name: "Base Requirements"}, Name: "Report", PrimaryKeyWord: "MAY"},
+	},
+	Source: "FedRAMP/docs@main",
+}
+
+func TestDocumentCategories(t *testing.T) {
+	var got []string
+	for _, g := range testDocument.Categories() {
+		got = append(got, fmt.Sprintf("%s:%d", g.ID, len(g.Requirements)))
+	}
+	if want := []string{"base:2", "AY:1"}; !slices.Equal(got, want) {
+		t.Errorf("Expected categories %v, got %v", want, got)
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteDocument(&buf, Markdown, testDocument); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"# VDR: Vulnerability Detection and Response",
+		"> Source: FedRAMP/docs@main",
+		"## Purpose\n\nFind and fix vulnerabilities.",
+		"- [OMB M-24-15](<https://example.gov/m-24-15>)",
+		"| 25.09A | 2025-09-10 | Initial release |",
+		"### Base Requirements\n\n#### FRR-VDR-01: Detect\n\n**MUST** · Impact: High",
+		`Providers MUST detect \<all> vulnerabilities.`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected Markdown to contain %q, got:\n%s", want, out)
+		}
+	}
+	// Requirements are grouped by category, not listed in document order
+	if strings.Index(out, "FRR-VDR-02") > strings.Index(out, "### Agencies") {
+		t.Errorf("Expected FRR-VDR-02 under Base Requirements, got:\n%s", out)
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteDocument(&buf, HTML, testDocument); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"<title>VDR: Vulnerability Detection and Response</title>",
+		"Source: FedRAMP/docs@main",
+		`<h3 id="base-requirements">Base Requirements</h3>`,
+		`<div class="item" id="FRR-VDR-01">`,
+		`<span class="badge must">MUST</span><span class="badge high">High</span>`,
+		"detect &lt;all&gt; vulnerabilities",
+		`<a href="https://example.gov/m-24-15">OMB M-24-15</a>`,
+		"<td>2025-09-10</td>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected HTML to contain %q", want)
+		}
+	}
+	if strings.Contains(out, "<link") || strings.Contains(out, "<script src") {
+		t.Error("Expected no external assets")
+	}
+}
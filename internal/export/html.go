@@ -0,0 +1,80 @@
+package export
+
+import (
+	"embed"
+	"html/template"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/catalog"
+	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// CSS is the stylesheet embedded in every exported HTML page, so pages need
+// no external assets
+var CSS = template.CSS(mustRead("templates/style.css"))
+
+// Funcs are the template functions available to HTML templates
+var Funcs = template.FuncMap{
+	"css":     func() template.CSS { return CSS },
+	"link":    htmlLink,
+	"lower":   strings.ToLower,
+	"join":    strings.Join,
+	"anchor":  Anchor,
+	"control": catalog.LabelFor,
+	"impacts": impactLevels,
+}
+
+var documentTemplate = template.Must(template.New("document.html").Funcs(Funcs).ParseFS(templates, "templates/document.html"))
+
+// WriteHTML writes the document as a single self-contained HTML page
+func WriteHTML(w io.Writer, d Document) error {
+	return documentTemplate.Execute(w, d)
+}
+
+func mustRead(name string) string {
+	data, err := templates.ReadFile(name)
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
+
+// htmlLink links text to url, or returns the text alone if there is no web
+// URL
+func htmlLink(text, url string) template.HTML {
+	if text == "" {
+		text = url
+	}
+	if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+		return template.HTML(template.HTMLEscapeString(text))
+	}
+	return template.HTML(`<a href="` + template.HTMLEscapeString(url) + `">` + template.HTMLEscapeString(text) + `</a>`)
+}
+
+// impactLevels returns the names of the impact levels that apply
+func impactLevels(i model.Impact) []string {
+	var levels []string
+	if i.Low {
+		levels = append(levels, "Low")
+	}
+	if i.Moderate {
+		levels = append(levels, "Moderate")
+	}
+	if i.High {
+		levels = append(levels, "High")
+	}
+	return levels
+}
+
+var nonAnchor = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Anchor turns a heading into a fragment identifier, e.g. "Cloud Service
+// Offerings" into "cloud-service-offerings"
+func Anchor(s string) string {
+	return strings.Trim(nonAnchor.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
@@ -0,0 +1,223 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/catalog"
+	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+)
+
+// WriteMarkdown writes the document as GitHub-flavored Markdown, for pasting
+// into wikis and SSP drafts
+func WriteMarkdown(w io.Writer, d Document) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s: %s\n\n", d.Code, mdText(d.Name))
+	fmt.Fprintf(&b, "> Source: %s", mdText(d.Source))
+	if fetched := d.Fetched(); fetched != "" {
+		fmt.Fprintf(&b, ", fetched %s", fetched)
+	}
+	b.WriteString("\n\n")
+	if d.Description != "" {
+		b.WriteString(mdText(d.Description) + "\n\n")
+	}
+	for _, warning := range d.Warnings() {
+		fmt.Fprintf(&b, "> **Warning:** %s\n\n", mdText(warning))
+	}
+
+	if d.Purpose != "" {
+		b.WriteString("## Purpose\n\n" + mdText(d.Purpose) + "\n\n")
+	}
+	if len(d.ExpectedOutcomes) > 0 {
+		b.WriteString("## Expected Outcomes\n\n")
+		for _, outcome := range d.ExpectedOutcomes {
+			b.WriteString("- " + mdText(outcome) + "\n")
+		}
+		b.WriteString("\n")
+	}
+	if len(d.Authority) > 0 {
+		b.WriteString("## Authority\n\n")
+		for _, a := range d.Authority {
+			b.WriteString("- " + mdLink(a.Reference, a.ReferenceURL))
+			if a.Description != "" {
+				b.WriteString(": " + mdText(a.Description))
+			}
+			b.WriteString("\n")
+			if a.Delegation != "" {
+				b.WriteString("  - Delegation: " + mdLink(a.Delegation, a.DelegationURL) + "\n")
+			}
+		}
+		b.WriteString("\n")
+	}
+	if len(d.EffectiveInfo) > 0 {
+		b.WriteString("## Program Status\n\n")
+		for _, eff := range d.EffectiveInfo {
+			fmt.Fprintf(&b, "- **%s**: %s", mdText(eff.Version), mdText(eff.Is))
+			if eff.CurrentStatus != "" {
+				fmt.Fprintf(&b, " (%s)", mdText(eff.CurrentStatus))
+			}
+			if eff.StartDate != "" {
+				fmt.Fprintf(&b, ", from %s", eff.StartDate)
+				if eff.EndDate != "" {
+					fmt.Fprintf(&b, " to %s", eff.EndDate)
+				}
+			}
+			b.WriteString("\n")
+			for _, warning := range eff.Warnings {
+				b.WriteString("  - **Warning:** " + mdText(warning) + "\n")
+			}
+		}
+		b.WriteString("\n")
+	}
+	if len(d.Releases) > 0 {
+		b.WriteString("## Releases\n\n| Release | Published | Description |\n|---|---|---|\n")
+		for _, r := range d.Releases {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", mdCell(r.ID), mdCell(r.PublishedDate), mdCell(r.Description))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(d.Requirements) > 0 {
+		b.WriteString("## Requirements\n\n")
+		for _, g := range d.Categories() {
+			if label := g.Label(); label != "" {
+				fmt.Fprintf(&b, "### %s\n\n", mdText(label))
+			}
+			if g.Application != "" {
+				b.WriteString("_" + mdText(g.Application) + "_\n\n")
+			}
+			for _, r := range g.Requirements {
+				writeMarkdownRequirement(&b, r)
+			}
+		}
+	}
+
+	if len(d.Indicators) > 0 {
+		b.WriteString("## Key Security Indicators\n\n")
+		for _, g := range d.Themes() {
+			fmt.Fprintf(&b, "### %s: %s\n\n", g.Code, mdText(g.Name))
+			if g.Description != "" {
+				b.WriteString(mdText(g.Description) + "\n\n")
+			}
+			for _, ind := range g.Indicators {
+				writeMarkdownIndicator(&b, ind)
+			}
+		}
+	}
+
+	if len(d.Definitions) > 0 {
+		b.WriteString("## Definitions\n\n")
+		for _, def := range d.Definitions {
+			fmt.Fprintf(&b, "#### %s\n\n", mdText(def.Term))
+			fmt.Fprintf(&b, "`%s`", def.ID)
+			if def.HasAlternatives() {
+				b.WriteString(" · Also: " + mdText(strings.Join(def.Alts, ", ")))
+			}
+			b.WriteString("\n\n" + mdText(def.Text) + "\n\n")
+			if def.Note != "" {
+				b.WriteString("> **Note:** " + mdText(def.Note) + "\n\n")
+			}
+			if def.HasReference() {
+				b.WriteString("Reference: " + mdLink(def.Reference, def.ReferenceURL) + "\n\n")
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, strings.TrimRight(b.String(), "\n")+"\n")
+	return err
+}
+
+func writeMarkdownRequirement(b *strings.Builder, r model.Requirement) {
+	fmt.Fprintf(b, "#### %s", r.ID)
+	if r.Name != "" {
+		b.WriteString(": " + mdText(r.Name))
+	}
+	b.WriteString("\n\n")
+
+	var badges []string
+	if r.PrimaryKeyWord != "" {
+		badges = append(badges, "**"+r.PrimaryKeyWord+"**")
+	}
+	if i := impact(r.Impact); i != "" {
+		badges = append(badges, "Impact: "+i)
+	}
+	if len(r.Affects) > 0 {
+		badges = append(badges, "Affects: "+mdText(strings.Join(r.Affects, ", ")))
+	}
+	if r.ParentID != "" {
+		badges = append(badges, "Part of "+r.ParentID)
+	}
+	if len(badges) > 0 {
+		b.WriteString(strings.Join(badges, " · ") + "\n\n")
+	}
+
+	if r.Statement != "" {
+		b.WriteString(mdText(r.Statement) + "\n\n")
+	}
+	if len(r.Following) > 0 {
+		for _, item := range r.Following {
+			b.WriteString("- " + mdText(item) + "\n")
+		}
+		b.WriteString("\n")
+	}
+	if r.Note != "" {
+		b.WriteString("> **Note:** " + mdText(r.Note) + "\n\n")
+	}
+}
+
+func writeMarkdownIndicator(b *strings.Builder, ind model.Indicator) {
+	fmt.Fprintf(b, "#### %s: %s\n\n", ind.ID, mdText(ind.Name))
+	var badges []string
+	if ind.Retired {
+		badges = append(badges, "**RETIRED**")
+	}
+	if i := impact(ind.Impact); i != "" {
+		badges = append(badges, "Impact: "+i)
+	}
+	if len(badges) > 0 {
+		b.WriteString(strings.Join(badges, " · ") + "\n\n")
+	}
+	if ind.Statement != "" {
+		b.WriteString(mdText(ind.Statement) + "\n\n")
+	}
+	if len(ind.Controls) > 0 {
+		var controls []string
+		for _, c := range ind.Controls {
+			controls = append(controls, fmt.Sprintf("%s %s", catalog.LabelFor(c.ControlID), mdText(c.Title)))
+		}
+		b.WriteString("Controls: " + strings.Join(controls, "; ") + "\n\n")
+	}
+	if ind.Note != "" {
+		b.WriteString("> **Note:** " + mdText(ind.Note) + "\n\n")
+	}
+}
+
+// mdEscaper escapes characters that Markdown would otherwise treat as
+// formatting inside running text
+var mdEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "<", `\<`, "[", `\[`, "]", `\]`,
+)
+
+// mdText escapes text and joins its lines, so it stays within its paragraph
+// or list item
+func mdText(s string) string {
+	return mdEscaper.Replace(strings.Join(strings.Fields(s), " "))
+}
+
+// mdCell escapes text for a table cell
+func mdCell(s string) string {
+	return strings.ReplaceAll(mdText(s), "|", `\|`)
+}
+
+// mdLink links text to url, or returns the text alone if there is no URL
+func mdLink(text, url string) string {
+	if text == "" {
+		text = url
+	}
+	if url == "" {
+		return mdText(text)
+	}
+	return fmt.Sprintf("[%s](<%s>)", mdText(text), url)
+}
@@ -0,0 +1,137 @@
+// Package export writes FedRAMP data to files for use outside the terminal,
+// such as spreadsheets
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/catalog"
+	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+)
+
+// Spreadsheet formats
+const (
+	CSV  = "csv"
+	XLSX = "xlsx"
+)
+
+// Table is a sheet of rows under a header. Every row ends with the source
+// the data was loaded from, so an exported file can be cited by version.
+type Table struct {
+	Name    string // Sheet name, e.g. "Requirements"
+	Columns []string
+	Rows    [][]string
+}
+
+// Requirements returns a row per requirement
+func Requirements(requirements []model.Requirement, source string) Table {
+	t := Table{
+		Name:    "Requirements",
+		Columns: []string{"ID", "Document", "Category", "Name", "Statement", "Keyword", "Impact", "Affects", "Source"},
+	}
+	for _, r := range requirements {
+		t.Rows = append(t.Rows, []string{
+			r.ID, r.DocumentCode, r.Category.Label(), r.Name, r.Statement, r.PrimaryKeyWord,
+			impact(r.Impact), strings.Join(r.Affects, ", "), source,
+		})
+	}
+	return t
+}
+
+// Indicators returns a row per indicator with its controls in one cell or,
+// with perControl, a row per control mapping
+func Indicators(indicators []model.Indicator, perControl bool, source string) Table {
+	t := Table{Name: "Indicators"}
+	if perControl {
+		t.Columns = []string{"ID", "Theme", "Name", "Statement", "Impact", "Status", "Control", "Control Title", "Source"}
+	} else {
+		t.Columns = []string{"ID", "Theme", "Name", "Statement", "Impact", "Status", "Controls", "Source"}
+	}
+	for _, ind := range indicators {
+		status := "active"
+		if ind.Retired {
+			status = "retired"
+		}
+		row := []string{ind.ID, ind.ThemeName, ind.Name, ind.Statement, impact(ind.Impact), status}
+		if !perControl {
+			var controls []string
+			for _, c := range ind.Controls {
+				controls = append(controls, catalog.LabelFor(c.ControlID))
+			}
+			t.Rows = append(t.Rows, append(row, strings.Join(controls, ", "), source))
+			continue
+		}
+		// Indicators without controls still get a row
+		if len(ind.Controls) == 0 {
+			t.Rows = append(t.Rows, append(row, "", "", source))
+		}
+		for _, c := range ind.Controls {
+			t.Rows = append(t.Rows, append(row[:len(row):len(row)], catalog.LabelFor(c.ControlID), c.Title, source))
+		}
+	}
+	return t
+}
+
+// Definitions returns a row per definition
+func Definitions(definitions []model.Definition, source string) Table {
+	t := Table{
+		Name:    "Definitions",
+		Columns: []string{"ID", "Term", "Alternatives", "Definition", "Note", "Reference", "Source"},
+	}
+	for _, d := range definitions {
+		reference := d.Reference
+		if d.ReferenceURL != "" {
+			reference = strings.TrimSpace(reference + " " + d.ReferenceURL)
+		}
+		t.Rows = append(t.Rows, []string{d.ID, d.Term, strings.Join(d.Alts, ", "), d.Text, d.Note, reference, source})
+	}
+	return t
+}
+
+// impact lists the impact levels, or nothing if there are none
+func impact(i model.Impact) string {
+	if !i.Low && !i.Moderate && !i.High {
+		return ""
+	}
+	return i.String()
+}
+
+// Write writes the table in the given format
+func Write(w io.Writer, format string, t Table) error {
+	switch format {
+	case CSV:
+		return WriteCSV(w, t)
+	case XLSX:
+		return WriteXLSX(w, t)
+	}
+	return fmt.Errorf("unknown spreadsheet format %q", format)
+}
+
+// WriteFile creates the named file and calls write with it
+func WriteFile(name string, write func(io.Writer) error) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	return f.Close()
+}
+
+// WriteCSV writes the table as CSV with a header row
+func WriteCSV(w io.Writer, t Table) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.Columns); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(t.Rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
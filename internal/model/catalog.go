@@ -0,0 +1,61 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CatalogControl is an SP 800-53 control or control enhancement from an
+// OSCAL catalog
+type CatalogControl struct {
+	ID           string // OSCAL ID, e.g. "ac-2.1"
+	Label        string // Display label, e.g. "AC-2(1)"
+	Title        string
+	Family       string // Family ID, e.g. "ac"
+	FamilyTitle  string
+	Parent       string // OSCAL ID of the base control, for enhancements
+	Statement    []StatementPart
+	Params       []ControlParam
+	Guidance     string
+	Enhancements []string // OSCAL IDs of the control's enhancements, in catalog order
+	Withdrawn    bool
+}
+
+// IsEnhancement returns true if the control enhances a base control
+func (c CatalogControl) IsEnhancement() bool {
+	return c.Parent != ""
+}
+
+// StatementPart is one item of a control statement, with parameters
+// already filled in with their placeholders
+type StatementPart struct {
+	Label string // e.g. "a." or "1."
+	Prose string
+	Depth int // Nesting level, 0 for the top-level statement
+}
+
+// ControlParam is an organization-defined parameter of a control
+type ControlParam struct {
+	ID         string
+	Label      string
+	HowMany    string   // For selections, e.g. "one-or-more"
+	Choices    []string // For selections
+	Guidelines []string
+}
+
+// Placeholder returns the parameter as written in SP 800-53, e.g.
+// "[Assignment: organization-defined frequency]"
+func (p ControlParam) Placeholder() string {
+	if len(p.Choices) > 0 {
+		kind := "Selection"
+		if p.HowMany != "" {
+			kind = fmt.Sprintf("Selection (%s)", strings.ReplaceAll(p.HowMany, "-", " "))
+		}
+		return fmt.Sprintf("[%s: %s]", kind, strings.Join(p.Choices, "; "))
+	}
+	label := p.Label
+	if label == "" {
+		label = p.ID
+	}
+	return fmt.Sprintf("[Assignment: %s]", label)
+}
@@ -2,13 +2,13 @@ package model
 
 // Definition represents a FedRAMP term definition
 type Definition struct {
-	ID           string
-	Term         string
-	Alts         []string
-	Text         string // The definition text
-	Note         string
-	Reference    string
-	ReferenceURL string
+	ID           string   `json:"id"`
+	Term         string   `json:"term"`
+	Alts         []string `json:"alternatives,omitempty"`
+	Text         string   `json:"definition"`
+	Note         string   `json:"note,omitempty"`
+	Reference    string   `json:"reference,omitempty"`
+	ReferenceURL string   `json:"reference_url,omitempty"`
 }
 
 // HasAlternatives returns true if there are alternative terms
@@ -1,39 +1,65 @@
 package model
 
+import "time"
+
 // Document represents a FedRAMP document category
 type Document struct {
-	Code             string
-	Name             string
-	Description      string
-	RequirementCount int
+	Code             string `json:"code"`
+	Name             string `json:"name"`
+	Description      string `json:"description,omitempty"`
+	RequirementCount int    `json:"requirement_count"`
+	IndicatorCount   int    `json:"indicator_count,omitempty"`  // KSI only
+	DefinitionCount  int    `json:"definition_count,omitempty"` // FRD only
 	// Rich metadata from JSON info section
-	Purpose          string
-	ExpectedOutcomes []string
-	Authority        []Authority
-	Releases         []Release
-	EffectiveInfo    map[string]EffectiveStatus
+	Purpose          string            `json:"purpose,omitempty"`
+	ExpectedOutcomes []string          `json:"expected_outcomes,omitempty"`
+	Authority        []Authority       `json:"authority,omitempty"`
+	Releases         []Release         `json:"releases,omitempty"`
+	EffectiveInfo    []EffectiveStatus `json:"effective,omitempty"` // In document order
+	// Load status
+	Stale     bool      `json:"stale"`      // Served from an expired cache entry because the refresh failed
+	FetchedAt time.Time `json:"fetched_at"` // When the data was last fetched or revalidated from its source
 }
 
 // Authority represents a legal authority reference
 type Authority struct {
-	Reference    string
-	ReferenceURL string
-	Description  string
+	Reference    string `json:"reference"`
+	ReferenceURL string `json:"reference_url,omitempty"`
+	Description  string `json:"description,omitempty"`
+	// Delegation of the authority to FedRAMP, if any
+	Delegation    string `json:"delegation,omitempty"`
+	DelegationURL string `json:"delegation_url,omitempty"`
 }
 
 // Release represents a document release version
 type Release struct {
-	ID            string
-	PublishedDate string
-	Description   string
+	ID            string `json:"id"`
+	PublishedDate string `json:"published_date,omitempty"`
+	Description   string `json:"description,omitempty"`
+	PublicComment bool   `json:"public_comment"` // The release was published for public comment
+	RelatedRFCs   []RFC  `json:"rfcs,omitempty"` // RFCs that led to or discuss the release
 }
 
 // EffectiveStatus represents program version status
 type EffectiveStatus struct {
-	Is            string
-	CurrentStatus string
-	StartDate     string
-	EndDate       string
-	SignupURL     string
-	Comments      []string
+	Version       string   `json:"version"` // Program version, e.g. "20x" or "rev5"
+	Is            string   `json:"is,omitempty"`
+	CurrentStatus string   `json:"current_status,omitempty"`
+	StartDate     string   `json:"start_date,omitempty"`
+	EndDate       string   `json:"end_date,omitempty"`
+	SignupURL     string   `json:"signup_url,omitempty"`
+	Comments      []string `json:"comments,omitempty"`
+	Warnings      []string `json:"warnings,omitempty"` // Caveats on whether this version still applies
+}
+
+// Warnings returns the effective-status warnings of every program version,
+// each prefixed with its version
+func (d Document) Warnings() []string {
+	var warnings []string
+	for _, eff := range d.EffectiveInfo {
+		for _, w := range eff.Warnings {
+			warnings = append(warnings, eff.Version+": "+w)
+		}
+	}
+	return warnings
 }
@@ -2,24 +2,24 @@ package model
 
 // Control represents an SP 800-53 control reference
 type Control struct {
-	ControlID string
-	Title     string
+	ControlID string `json:"control_id"`
+	Title     string `json:"title"`
 }
 
 // Indicator represents a Key Security Indicator
 type Indicator struct {
-	ID           string
-	ThemeCode    string
-	ThemeName    string
-	ThemeDesc    string
-	Name         string
-	Statement    string
-	Impact       Impact
-	Controls     []Control
-	Reference    string
-	ReferenceURL string
-	Note         string
-	Retired      bool
+	ID           string    `json:"id"`
+	ThemeCode    string    `json:"theme"`
+	ThemeName    string    `json:"theme_name"`
+	ThemeDesc    string    `json:"theme_description,omitempty"`
+	Name         string    `json:"name"`
+	Statement    string    `json:"statement"`
+	Impact       Impact    `json:"impact"`
+	Controls     []Control `json:"controls"`
+	Reference    string    `json:"reference,omitempty"`
+	ReferenceURL string    `json:"reference_url,omitempty"`
+	Note         string    `json:"note,omitempty"`
+	Retired      bool      `json:"retired"`
 }
 
 // HasControls returns true if the indicator has control mappings
@@ -1,6 +1,9 @@
 package model
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // DocumentItem wraps Document for the list component
 type DocumentItem struct {
@@ -12,10 +15,20 @@ func (d DocumentItem) Title() string {
 }
 
 func (d DocumentItem) Description() string {
+	var counts []string
 	if d.RequirementCount > 0 {
-		return fmt.Sprintf("%s (%d requirements)", d.Document.Description, d.RequirementCount)
+		counts = append(counts, fmt.Sprintf("%d requirements", d.RequirementCount))
 	}
-	return d.Document.Description
+	if d.IndicatorCount > 0 {
+		counts = append(counts, fmt.Sprintf("%d indicators", d.IndicatorCount))
+	}
+	if d.DefinitionCount > 0 {
+		counts = append(counts, fmt.Sprintf("%d definitions", d.DefinitionCount))
+	}
+	if len(counts) == 0 {
+		return d.Document.Description
+	}
+	return fmt.Sprintf("%s (%s)", d.Document.Description, strings.Join(counts, ", "))
 }
 
 func (d DocumentItem) FilterValue() string {
@@ -40,12 +53,32 @@ func (r RequirementItem) Description() string {
 }
 
 func (r RequirementItem) FilterValue() string {
-	return r.ID + " " + r.Name + " " + r.Statement + " " + r.DocumentCode
+	return r.ID + " " + r.Name + " " + r.Statement + " " + r.DocumentCode + " " + r.Category.Label()
+}
+
+// CategoryItem heads a group of requirements when grouping by category
+type CategoryItem struct {
+	Category
+	DocumentCode string
+	Count        int
+}
+
+func (c CategoryItem) Title() string {
+	return fmt.Sprintf("%s (%d)", c.Label(), c.Count)
+}
+
+func (c CategoryItem) Description() string {
+	return c.Application
+}
+
+func (c CategoryItem) FilterValue() string {
+	return c.DocumentCode + " " + c.Label()
 }
 
 // DefinitionItem wraps Definition for the list component
 type DefinitionItem struct {
 	Definition
+	Usages int // Number of requirements and indicators that use the term
 }
 
 func (d DefinitionItem) Title() string {
@@ -92,3 +125,67 @@ func (i IndicatorItem) Description() string {
 func (i IndicatorItem) FilterValue() string {
 	return i.ID + " " + i.Name + " " + i.Statement + " " + i.ThemeName
 }
+
+// RFCItem wraps RFC for the list component
+type RFCItem struct {
+	RFC
+}
+
+func (r RFCItem) Title() string {
+	return r.RFC.Title()
+}
+
+func (r RFCItem) Description() string {
+	return fmt.Sprintf("%s | %s to %s | %s", r.ID, r.StartDate, r.EndDate, strings.Join(r.DocumentCodes, ", "))
+}
+
+func (r RFCItem) FilterValue() string {
+	return r.ID + " " + r.ShortName + " " + r.FullName + " " + strings.Join(r.DocumentCodes, " ")
+}
+
+// CatalogControlItem wraps CatalogControl for the list component
+type CatalogControlItem struct {
+	CatalogControl
+	Coverage int // Number of KSIs that map to the control
+}
+
+func (c CatalogControlItem) Title() string {
+	return fmt.Sprintf("%s %s", c.Label, c.CatalogControl.Title)
+}
+
+func (c CatalogControlItem) Description() string {
+	return c.FamilyTitle
+}
+
+func (c CatalogControlItem) FilterValue() string {
+	return c.Label + " " + c.ID + " " + c.CatalogControl.Title + " " + c.FamilyTitle
+}
+
+// ControlFamilyItem heads the controls of an SP 800-53 family, with how many
+// of them KSIs cover
+type ControlFamilyItem struct {
+	ID         string // e.g. "AC"
+	Name       string
+	Mapped     int // Controls that at least one KSI maps to
+	Total      int // Controls listed in the family; 0 if unknown
+	Indicators int // Distinct KSIs that map to a control in the family
+}
+
+func (f ControlFamilyItem) Title() string {
+	if f.Name == "" {
+		return f.ID
+	}
+	return fmt.Sprintf("%s %s", f.ID, f.Name)
+}
+
+func (f ControlFamilyItem) Description() string {
+	controls := fmt.Sprintf("%d controls", f.Mapped)
+	if f.Total > 0 {
+		controls = fmt.Sprintf("%d of %d controls", f.Mapped, f.Total)
+	}
+	return fmt.Sprintf("%s mapped by %d KSIs", controls, f.Indicators)
+}
+
+func (f ControlFamilyItem) FilterValue() string {
+	return f.ID + " " + f.Name
+}
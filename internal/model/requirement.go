@@ -2,9 +2,9 @@ package model
 
 // Impact represents the impact levels for a requirement
 type Impact struct {
-	Low      bool
-	Moderate bool
-	High     bool
+	Low      bool `json:"low"`
+	Moderate bool `json:"moderate"`
+	High     bool `json:"high"`
 }
 
 // ImpactString returns a human-readable string of impact levels
@@ -29,16 +29,37 @@ func (i Impact) String() string {
 	return result
 }
 
+// Category groups related requirements within a document
+type Category struct {
+	ID          string `json:"id"`
+	Name        string `json:"name,omitempty"`
+	Application string `json:"application,omitempty"` // Describes who or what the category's requirements apply to
+}
+
+// Label returns the category name, or its ID if it has none
+func (c Category) Label() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return c.ID
+}
+
 // Requirement represents a FedRAMP requirement
 type Requirement struct {
-	ID             string
-	DocumentCode   string
-	Statement      string
-	Name           string
-	Impact         Impact
-	Affects        []string
-	PrimaryKeyWord string
-	Note           string
+	ID             string   `json:"id"`
+	DocumentCode   string   `json:"document"`
+	Category       Category `json:"category"`
+	Statement      string   `json:"statement"`
+	Name           string   `json:"name,omitempty"`
+	Impact         Impact   `json:"impact"`
+	Affects        []string `json:"affects"`
+	PrimaryKeyWord string   `json:"keyword,omitempty"`
+	Note           string   `json:"note,omitempty"`
+
+	// Requirements may list sub-requirements ("MUST include the following")
+	ParentID  string   `json:"parent_id,omitempty"` // ID of the requirement this one is part of
+	Children  []string `json:"children,omitempty"`  // IDs of sub-requirements, in document order
+	Following []string `json:"following,omitempty"` // Plain text items that follow the statement, in document order
 }
 
 // IsMust returns true if this is a MUST requirement
@@ -0,0 +1,137 @@
+package model
+
+import (
+	"slices"
+	"sort"
+	"time"
+)
+
+// RFC is a FedRAMP request for comment related to a document release
+type RFC struct {
+	ID            string `json:"id"`
+	URL           string `json:"url,omitempty"`
+	DiscussionURL string `json:"discussion_url,omitempty"`
+	ShortName     string `json:"short_name,omitempty"`
+	FullName      string `json:"full_name,omitempty"`
+	StartDate     string `json:"start_date,omitempty"` // Start of the public comment period (YYYY-MM-DD)
+	EndDate       string `json:"end_date,omitempty"`   // Last day of the public comment period (YYYY-MM-DD)
+
+	// Documents and releases that reference the RFC, filled in when RFCs
+	// are collected across documents
+	DocumentCodes []string `json:"documents,omitempty"`
+	ReleaseIDs    []string `json:"releases,omitempty"`
+}
+
+// CommentStatus describes where an RFC's comment period stands
+type CommentStatus int
+
+const (
+	CommentUnknown  CommentStatus = iota // No parseable comment period
+	CommentUpcoming                      // The comment period has not started
+	CommentOpen                          // Comments are being accepted
+	CommentClosed                        // The comment period has ended
+)
+
+func (s CommentStatus) String() string {
+	switch s {
+	case CommentUpcoming:
+		return "upcoming"
+	case CommentOpen:
+		return "open"
+	case CommentClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+const dateLayout = "2006-01-02"
+
+// CommentWindow returns the comment period. The end is exclusive: the end of
+// the last day the period is open.
+func (r RFC) CommentWindow() (start, end time.Time, ok bool) {
+	start, err := time.Parse(dateLayout, r.StartDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	end, err = time.Parse(dateLayout, r.EndDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, end.AddDate(0, 0, 1), true
+}
+
+// Status returns the state of the comment period on the given day. Dates are
+// compared by calendar day, so an RFC is open through its end date.
+func (r RFC) Status(now time.Time) CommentStatus {
+	start, end, ok := r.CommentWindow()
+	if !ok {
+		return CommentUnknown
+	}
+	today, _ := time.Parse(dateLayout, now.Format(dateLayout))
+	switch {
+	case today.Before(start):
+		return CommentUpcoming
+	case today.Before(end):
+		return CommentOpen
+	default:
+		return CommentClosed
+	}
+}
+
+// DaysLeft returns the number of days, including today, that comments are
+// still accepted
+func (r RFC) DaysLeft(now time.Time) int {
+	_, end, ok := r.CommentWindow()
+	if !ok {
+		return 0
+	}
+	today, _ := time.Parse(dateLayout, now.Format(dateLayout))
+	return max(int(end.Sub(today).Hours()/24), 0)
+}
+
+// Title returns the RFC's most descriptive name
+func (r RFC) Title() string {
+	switch {
+	case r.FullName != "":
+		return r.FullName
+	case r.ShortName != "":
+		return r.ShortName
+	}
+	return r.ID
+}
+
+// CollectRFCs gathers the RFCs related to every release of the given
+// documents. An RFC referenced by several documents or releases is listed
+// once with all of them. RFCs are returned newest comment period first.
+func CollectRFCs(documents []Document) []RFC {
+	var rfcs []RFC
+	index := make(map[string]int)
+	for _, doc := range documents {
+		for _, rel := range doc.Releases {
+			for _, rfc := range rel.RelatedRFCs {
+				i, ok := index[rfc.ID]
+				if !ok {
+					i = len(rfcs)
+					index[rfc.ID] = i
+					rfc.DocumentCodes, rfc.ReleaseIDs = nil, nil
+					rfcs = append(rfcs, rfc)
+				}
+				if !slices.Contains(rfcs[i].DocumentCodes, doc.Code) {
+					rfcs[i].DocumentCodes = append(rfcs[i].DocumentCodes, doc.Code)
+				}
+				if !slices.Contains(rfcs[i].ReleaseIDs, rel.ID) {
+					rfcs[i].ReleaseIDs = append(rfcs[i].ReleaseIDs, rel.ID)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(rfcs, func(i, j int) bool {
+		if rfcs[i].StartDate != rfcs[j].StartDate {
+			return rfcs[i].StartDate > rfcs[j].StartDate
+		}
+		return rfcs[i].ID < rfcs[j].ID
+	})
+	return rfcs
+}
@@ -0,0 +1,119 @@
+//go:build ignore
+
+// gen vendors the FRMR schema that FedRAMP/docs documents declare into
+// frmr.schema.json. Run it with `go generate ./internal/schema` and commit the
+// result.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/api"
+	"github.com/ethanolivertroy/fedramp-tui/internal/schema"
+)
+
+const commitsURL = "https://api.github.com/repos/FedRAMP/docs/commits/"
+
+func main() {
+	ref := flag.String("ref", "main", "FedRAMP/docs branch, tag or commit whose documents name the schema")
+	flag.Parse()
+
+	if err := run(*ref); err != nil {
+		fmt.Fprintf(os.Stderr, "schema: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ref string) error {
+	client := &http.Client{Timeout: 60 * time.Second}
+
+	sha, err := resolveCommit(client, ref)
+	if err != nil {
+		return fmt.Errorf("resolving %s: %w", ref, err)
+	}
+	source := api.NewHTTPSource("https://raw.githubusercontent.com/FedRAMP/docs/" + sha)
+
+	listed, err := source.List(context.Background())
+	if err != nil {
+		return fmt.Errorf("listing documents: %w", err)
+	}
+
+	// Every document must declare the same schema, or there is no single one
+	// to bundle
+	var url string
+	for _, filename := range listed {
+		if _, ok := api.ParseDocumentFilename(filename); !ok {
+			continue
+		}
+		data, err := source.Fetch(context.Background(), filename)
+		if err != nil {
+			return fmt.Errorf("fetching %s: %w", filename, err)
+		}
+		declared := schema.Declared(data)
+		switch {
+		case declared == "" || schema.IsMetaSchema(declared):
+			return fmt.Errorf("%s does not declare an FRMR schema", filename)
+		case url == "":
+			url = declared
+		case declared != url:
+			return fmt.Errorf("%s declares %s, other documents %s", filename, declared, url)
+		}
+	}
+	if url == "" {
+		return fmt.Errorf("no FRMR documents at %s", sha)
+	}
+
+	data, err := get(client, url)
+	if err != nil {
+		return fmt.Errorf("fetching %s: %w", url, err)
+	}
+
+	// Refuse to bundle a schema the validator cannot use
+	if _, err := schema.Parse(data); err != nil {
+		return fmt.Errorf("%s: %w", url, err)
+	}
+	if err := os.WriteFile("frmr.schema.json", data, 0644); err != nil {
+		return err
+	}
+
+	fmt.Printf("schema: bundled %s from FedRAMP/docs@%s\n", url, sha[:7])
+	return nil
+}
+
+func get(client *http.Client, url string) ([]byte, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func resolveCommit(client *http.Client, ref string) (string, error) {
+	data, err := get(client, commitsURL+ref)
+	if err != nil {
+		return "", err
+	}
+
+	var commit struct {
+		SHA string `json:"sha"`
+	}
+	if err := json.Unmarshal(data, &commit); err != nil {
+		return "", err
+	}
+	if len(commit.SHA) < 7 {
+		return "", fmt.Errorf("unexpected commit SHA %q", commit.SHA)
+	}
+	return commit.SHA, nil
+}
@@ -0,0 +1,185 @@
+// Package schema validates FRMR documents against a JSON Schema, using
+// github.com/santhosh-tekuri/jsonschema for the validation itself. Schemas
+// may use any draft from 4 through 2020-12.
+package schema
+
+//go:generate go run gen.go
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// bundled is the FRMR schema embedded in the binary. `go generate
+// ./internal/schema` replaces it with the schema that FedRAMP/docs documents
+// declare. Until that has been run and committed, it is a hand-written
+// stand-in covering what the parsers in internal/api read, not the upstream
+// schema.
+//
+//go:embed frmr.schema.json
+var bundled []byte
+
+// BundledID is the $id of the bundled FRMR schema
+const BundledID = "bundled"
+
+// printer renders violation messages
+var printer = message.NewPrinter(language.English)
+
+// baseURL locates schemas that do not declare an absolute $id. It is never
+// fetched: the reserved .invalid domain does not resolve, and noLoader
+// refuses it anyway.
+const baseURL = "https://fedramp-tui.invalid/schema.json"
+
+// Violation is a place where a document does not match its schema
+type Violation struct {
+	Pointer string `json:"pointer"` // JSON pointer to the offending value; "" is the document root
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	pointer := v.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+	return pointer + ": " + v.Message
+}
+
+// Schema is a parsed JSON Schema
+type Schema struct {
+	compiled *jsonschema.Schema
+}
+
+// noLoader refuses to load other documents, so schemas never touch the
+// network and every $ref must resolve within the schema
+type noLoader struct{}
+
+func (noLoader) Load(url string) (any, error) {
+	return nil, fmt.Errorf("cannot resolve $ref to %s; only references within the schema are supported", url)
+}
+
+// Parse parses a JSON Schema document. Every $ref must resolve within the
+// document, since a reference that cannot be followed would silently accept
+// anything in its place.
+func Parse(data []byte) (*Schema, error) {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("parsing schema: %w", err)
+	}
+
+	url := baseURL
+	switch d := doc.(type) {
+	case map[string]any:
+		if id, ok := d["$id"].(string); ok && strings.Contains(id, "://") {
+			url = strings.TrimSuffix(id, "#")
+		}
+	case bool:
+	default:
+		return nil, fmt.Errorf("parsing schema: expected object or boolean, got %T", doc)
+	}
+
+	c := jsonschema.NewCompiler()
+	c.UseLoader(noLoader{})
+	if err := c.AddResource(url, doc); err != nil {
+		return nil, fmt.Errorf("parsing schema: %w", err)
+	}
+	compiled, err := c.Compile(url)
+	if err != nil {
+		return nil, fmt.Errorf("parsing schema: %w", err)
+	}
+	return &Schema{compiled: compiled}, nil
+}
+
+var (
+	bundledOnce   sync.Once
+	bundledSchema *Schema
+)
+
+// Bundled returns the FRMR schema shipped with the binary, used when a
+// document's declared schema cannot be retrieved
+func Bundled() *Schema {
+	bundledOnce.Do(func() {
+		s, err := Parse(bundled)
+		if err != nil {
+			panic(err)
+		}
+		bundledSchema = s
+	})
+	return bundledSchema
+}
+
+// IsMetaSchema returns true if url names a JSON Schema dialect (e.g.
+// https://json-schema.org/draft/2020-12/schema) rather than a schema for the
+// document itself
+func IsMetaSchema(url string) bool {
+	return strings.Contains(url, "json-schema.org/")
+}
+
+// Declared returns the $schema URL a document declares, if any
+func Declared(data []byte) string {
+	var doc struct {
+		Schema string `json:"$schema"`
+	}
+	_ = json.Unmarshal(data, &doc)
+	return doc.Schema
+}
+
+// Validate checks a JSON document against the schema and returns the
+// violations ordered by pointer. The error is non-nil only when data is not
+// valid JSON.
+func (s *Schema) Validate(data []byte) ([]Violation, error) {
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []Violation
+	var verr *jsonschema.ValidationError
+	if err := s.compiled.Validate(instance); errors.As(err, &verr) {
+		collect(verr, &violations)
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Group reports by location regardless of how the schema is structured
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Pointer < violations[j].Pointer
+	})
+	return violations, nil
+}
+
+// collect flattens a validation error into violations. Errors that only say
+// their causes failed (the whole schema, a $ref, allOf) are replaced by those
+// causes; the rest, including anyOf and oneOf, are reported as they are,
+// since the branches that failed to match are not problems in themselves.
+func collect(err *jsonschema.ValidationError, out *[]Violation) {
+	switch err.ErrorKind.(type) {
+	case *kind.Schema, *kind.Group, *kind.Reference, *kind.AllOf:
+		if len(err.Causes) > 0 {
+			for _, cause := range err.Causes {
+				collect(cause, out)
+			}
+			return
+		}
+	}
+	*out = append(*out, Violation{Pointer: pointer(err.InstanceLocation), Message: err.ErrorKind.LocalizedString(printer)})
+}
+
+// pointer encodes tokens as a JSON pointer
+func pointer(tokens []string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString("/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(token))
+	}
+	return b.String()
+}
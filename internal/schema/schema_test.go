@@ -0,0 +1,151 @@
+package schema
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBundledSchema(t *testing.T) {
+	valid := `{
+		"info": {"name": "Vulnerability Detection and Response", "short_name": "VDR", "releases": [{"id": "25.09A", "public_comment": false}]},
+		"FRR": {"VDR": {"base": {"id": "base", "requirements": [
+			{"id": "FRR-VDR-01", "statement": "Providers MUST...", "impact": {"low": true},
+			 "following_information": [{"id": "FRR-VDR-01-a", "statement": "first"}, "a string item"]}
+		]}}},
+		"KSI": {"CED": {"name": "Cybersecurity Education", "indicators": [{"id": "KSI-CED-01", "controls": [{"control_id": "at-2"}]}]}}
+	}`
+	violations, err := Bundled().Validate([]byte(valid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) > 0 {
+		t.Errorf("Expected no violations, got %v", violations)
+	}
+
+	invalid := `{
+		"info": {"name": "Broken"},
+		"FRR": {"VDR": {"requirements": [{"statement": "no id", "impact": {"low": "yes"}}]}},
+		"KSI": {"CED": {"indicators": [{"id": "KSI-CED-01", "controls": "at-2"}]}}
+	}`
+	violations, err = Bundled().Validate([]byte(invalid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, v := range violations {
+		got = append(got, v.String())
+	}
+	want := []string{
+		`/FRR/VDR/requirements/0: missing property 'id'`,
+		`/FRR/VDR/requirements/0/impact/low: got string, want boolean`,
+		`/KSI/CED/indicators/0/controls: got string, want array`,
+		`/info: missing property 'short_name'`,
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Unexpected violations:\n got  %q\n want %q", got, want)
+	}
+
+	if _, err := Bundled().Validate([]byte("{")); err == nil {
+		t.Error("Expected error for malformed JSON")
+	}
+}
+
+func TestValidateKeywords(t *testing.T) {
+	s, err := Parse([]byte(`{
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"level": {"enum": ["low", "moderate", "high"]},
+			"count": {"type": "integer", "minimum": 1},
+			"id": {"type": "string", "pattern": "^FRR-[A-Z]+-[0-9]+$"},
+			"a/b": {"oneOf": [{"type": "string"}, {"type": "integer"}]}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	violations, err := s.Validate([]byte(`{"level": "extreme", "count": 0.5, "id": "VDR-1", "a/b": true, "extra": 1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pointers := make([]string, len(violations))
+	for i, v := range violations {
+		pointers[i] = v.Pointer
+	}
+	// oneOf is reported once, not once per branch that failed
+	want := []string{"", "/a~1b", "/count", "/id", "/level"}
+	if !slices.Equal(pointers, want) {
+		t.Errorf("Expected violations at %q, got %v", want, violations)
+	}
+
+	if violations, err := s.Validate([]byte(`{"level": "low"}`)); err != nil || len(violations) > 0 {
+		t.Errorf("Expected a valid document, got %v (%v)", violations, err)
+	}
+	if _, err := s.Validate([]byte(`{"level": "low"} {}`)); err == nil {
+		t.Error("Expected error for trailing data")
+	}
+}
+
+func TestValidateRefsAndComposition(t *testing.T) {
+	s, err := Parse([]byte(`{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"definitions": {"id": {"type": "string", "minLength": 3}},
+		"type": "array",
+		"items": {
+			"allOf": [{"$ref": "#/definitions/id"}, {"anyOf": [{"pattern": "^FRR-"}, {"pattern": "^KSI-"}]}]
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	violations, err := s.Validate([]byte(`["FRR-VDR-01", "X", "ABC-1"]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, v := range violations {
+		got = append(got, v.Pointer)
+	}
+	// allOf and $ref are replaced by what failed inside them; anyOf is one violation
+	want := []string{"/1", "/1", "/2"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Expected violations at %q, got %v", want, violations)
+	}
+}
+
+func TestIsMetaSchema(t *testing.T) {
+	if !IsMetaSchema("https://json-schema.org/draft/2020-12/schema") {
+		t.Error("Expected draft 2020-12 to be a meta-schema")
+	}
+	if IsMetaSchema("https://raw.githubusercontent.com/FedRAMP/docs/main/schemas/FRMR.schema.json") {
+		t.Error("Expected FRMR schema not to be a meta-schema")
+	}
+}
+
+func TestUnresolvableRef(t *testing.T) {
+	// Errors name the unresolvable part: the pointer, or the other document
+	refs := map[string]string{
+		"#/$defs/missing": "/$defs/missing",
+		"https://example.com/other.json#/$defs/item": "https://example.com/other.json",
+		"other.json": "other.json",
+	}
+	for ref, want := range refs {
+		_, err := Parse([]byte(`{"properties": {"item": {"$ref": "` + ref + `"}}, "$defs": {"item": {"type": "string"}}}`))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected an error naming %q for $ref %q, got %v", want, ref, err)
+		}
+	}
+
+	// References qualified with the schema's own $id resolve locally, and
+	// "$ref" inside enum values is data
+	s, err := Parse([]byte(`{"$id": "https://example.com/s.json", "properties": {"item": {"$ref": "https://example.com/s.json#/$defs/item"}, "kind": {"enum": [{"$ref": "x"}]}}, "$defs": {"item": {"type": "string"}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if violations, _ := s.Validate([]byte(`{"item": 1}`)); len(violations) != 1 {
+		t.Errorf("Expected the referenced schema to be checked, got %v", violations)
+	}
+}
@@ -0,0 +1,311 @@
+// Package site generates a static HTML site of the whole dataset. Pages link
+// to each other with relative URLs and need no external assets, so the site
+// works offline, e.g. opened from a file share.
+package site
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/export"
+	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+	"github.com/ethanolivertroy/fedramp-tui/internal/store"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// siteCSS styles the site's header, footer and search, on top of export.CSS
+var siteCSS = template.CSS(mustRead("templates/site.css"))
+
+var pages = template.Must(template.New("site").Funcs(export.Funcs).Funcs(template.FuncMap{
+	"siteCSS": func() template.CSS { return siteCSS },
+}).ParseFS(templates, "templates/*.html"))
+
+// Directories holding a page per item, by kind
+var kindDirs = map[store.RefKind]string{
+	store.RefRequirement: "requirements",
+	store.RefIndicator:   "indicators",
+	store.RefDefinition:  "definitions",
+}
+
+// Site is the static site for a store
+type Site struct {
+	store   *store.Store
+	Source  string // Where the data was loaded from, e.g. "FedRAMP/docs@main"
+	Fetched string // Date of the oldest fetch, so the site never looks fresher than it is
+}
+
+// New returns the site for a store loaded from source
+func New(s *store.Store, source string) *Site {
+	site := &Site{store: s, Source: source}
+	var oldest time.Time
+	for _, d := range s.Documents() {
+		if !d.FetchedAt.IsZero() && (oldest.IsZero() || d.FetchedAt.Before(oldest)) {
+			oldest = d.FetchedAt
+		}
+	}
+	if !oldest.IsZero() {
+		site.Fetched = oldest.UTC().Format(time.DateOnly)
+	}
+	return site
+}
+
+// Write writes the site into dir, creating it if needed, and returns the
+// number of pages written
+func (s *Site) Write(dir string) (int, error) {
+	for _, sub := range []string{"documents", "requirements", "indicators", "definitions"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return 0, err
+		}
+	}
+
+	n := 0
+	// Names are escaped reversibly, but IDs differing only in case would still
+	// share a file on case-insensitive filesystems
+	written := make(map[string]string)
+	write := func(path, name string, p page) error {
+		key := strings.ToLower(path)
+		if other, ok := written[key]; ok {
+			return fmt.Errorf("%s and %s would both be written to %s", other, p.Self, path)
+		}
+		written[key] = p.Self
+		f, err := os.Create(filepath.Join(dir, path))
+		if err != nil {
+			return err
+		}
+		if err := pages.ExecuteTemplate(f, name, p); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("rendering %s: %w", path, err)
+		}
+		n++
+		return f.Close()
+	}
+
+	if err := write("index.html", "index.html", s.page("", "FedRAMP Documents", "", s.store.Documents())); err != nil {
+		return n, err
+	}
+	for _, d := range s.store.Documents() {
+		doc, _ := export.NewDocument(s.store, d.Code, s.Source)
+		if err := write(filepath.Join("documents", fileName(d.Code)), "document.html", s.page("../", d.Code+": "+d.Name, d.Code, doc)); err != nil {
+			return n, err
+		}
+	}
+	for _, r := range s.store.Requirements() {
+		if err := write(filepath.Join("requirements", fileName(r.ID)), "requirement.html", s.page("../", r.ID, r.ID, r)); err != nil {
+			return n, err
+		}
+	}
+	for _, ind := range s.store.Indicators() {
+		if err := write(filepath.Join("indicators", fileName(ind.ID)), "indicator.html", s.page("../", ind.ID, ind.ID, ind)); err != nil {
+			return n, err
+		}
+	}
+	for _, def := range s.store.Definitions() {
+		if err := write(filepath.Join("definitions", fileName(def.ID)), "definition.html", s.page("../", def.Term, def.ID, def)); err != nil {
+			return n, err
+		}
+	}
+
+	if err := s.writeSearchIndex(filepath.Join(dir, "search-index.js")); err != nil {
+		return n, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "search.js"), []byte(mustRead("templates/search.js")), 0o644); err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// entry is an item in the client-side search index
+type entry struct {
+	ID    string `json:"id"`
+	Kind  string `json:"kind"`
+	Title string `json:"title"`
+	Text  string `json:"text"`
+	URL   string `json:"url"` // Relative to the site root
+}
+
+// writeSearchIndex writes every item as a script rather than as JSON, since
+// browsers will not fetch JSON from file: URLs
+func (s *Site) writeSearchIndex(path string) error {
+	root := s.page("", "", "", nil)
+	var entries []entry
+	for _, d := range s.store.Documents() {
+		entries = append(entries, entry{d.Code, "document", d.Name, d.Description, root.DocumentURL(d.Code)})
+	}
+	for _, r := range s.store.Requirements() {
+		text := strings.Join(append([]string{r.Statement, r.Note}, r.Following...), " ")
+		entries = append(entries, entry{r.ID, "requirement", r.Name, text, root.URL(store.RefRequirement, r.ID)})
+	}
+	for _, ind := range s.store.Indicators() {
+		entries = append(entries, entry{ind.ID, "indicator", ind.Name, ind.Statement + " " + ind.Note, root.URL(store.RefIndicator, ind.ID)})
+	}
+	for _, def := range s.store.Definitions() {
+		text := strings.Join(append([]string{def.Text, def.Note}, def.Alts...), " ")
+		entries = append(entries, entry{def.ID, "definition", def.Term, text, root.URL(store.RefDefinition, def.ID)})
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte("window.FEDRAMP_INDEX = "+string(data)+";\n"), 0o644)
+}
+
+// page is what a page template renders
+type page struct {
+	*Site
+	Title string
+	Root  string // Relative URL of the site root from the page, e.g. "../"
+	Self  string // ID of the item the page is about, which it does not link to
+	Item  any
+}
+
+func (s *Site) page(root, title, self string, item any) page {
+	return page{Site: s, Title: title, Root: root, Self: self, Item: item}
+}
+
+// Link is a link to an item's page
+type Link struct {
+	ID    string
+	Title string
+	URL   string
+}
+
+// URL returns the relative URL of an item's page
+func (p page) URL(kind store.RefKind, id string) string {
+	return p.Root + kindDirs[kind] + "/" + fileName(id)
+}
+
+// DocumentURL returns the relative URL of a document's page
+func (p page) DocumentURL(code string) string {
+	return p.Root + "documents/" + fileName(code)
+}
+
+// Document returns the document with the given code, or one with just the
+// code if it is not loaded
+func (p page) Document(code string) model.Document {
+	if d, ok := p.store.Document(code); ok {
+		return d
+	}
+	return model.Document{Code: code}
+}
+
+// Requirement links to a requirement
+func (p page) Requirement(id string) Link {
+	return p.link(store.RefRequirement, id)
+}
+
+// Indicator links to an indicator
+func (p page) Indicator(id string) Link {
+	return p.link(store.RefIndicator, id)
+}
+
+// Definition links to a definition
+func (p page) Definition(id string) Link {
+	return p.link(store.RefDefinition, id)
+}
+
+// Indicators links to the indicators mapped to a control
+func (p page) Indicators(controlID string) []Link {
+	var links []Link
+	for _, ind := range p.store.IndicatorsForControl(controlID) {
+		if ind.ID != p.Self {
+			links = append(links, p.link(store.RefIndicator, ind.ID))
+		}
+	}
+	return links
+}
+
+// UsedBy links to the requirements and indicators that use a definition
+func (p page) UsedBy(definitionID string) []Link {
+	var links []Link
+	for _, u := range p.store.Usages(definitionID) {
+		links = append(links, p.link(u.Kind, u.ID))
+	}
+	return links
+}
+
+// Related links to the items mentioned in texts, other than the page's own
+func (p page) Related(texts ...string) []Link {
+	var links []Link
+	seen := map[string]bool{p.Self: true}
+	for _, text := range texts {
+		for _, ref := range p.store.References(text) {
+			if !seen[ref.ID] {
+				seen[ref.ID] = true
+				links = append(links, p.link(ref.Kind, ref.ID))
+			}
+		}
+	}
+	return links
+}
+
+// Linked escapes text and links the IDs and defined terms it mentions to
+// their pages
+func (p page) Linked(text string) template.HTML {
+	var b strings.Builder
+	last := 0
+	for _, ref := range p.store.References(text) {
+		if ref.ID == p.Self {
+			continue
+		}
+		l := p.link(ref.Kind, ref.ID)
+		b.WriteString(template.HTMLEscapeString(text[last:ref.Start]))
+		fmt.Fprintf(&b, `<a href="%s" title="%s">%s</a>`,
+			template.HTMLEscapeString(l.URL), template.HTMLEscapeString(l.Title), template.HTMLEscapeString(text[ref.Start:ref.End]))
+		last = ref.End
+	}
+	b.WriteString(template.HTMLEscapeString(text[last:]))
+	return template.HTML(b.String())
+}
+
+// link links to an item, titled with its name
+func (p page) link(kind store.RefKind, id string) Link {
+	l := Link{ID: id, URL: p.URL(kind, id)}
+	switch kind {
+	case store.RefRequirement:
+		if r, ok := p.store.Requirement(id); ok {
+			l.Title = r.Name
+		}
+	case store.RefIndicator:
+		if ind, ok := p.store.Indicator(id); ok {
+			l.Title = ind.Name
+		}
+	case store.RefDefinition:
+		if def, ok := p.store.Definition(id); ok {
+			l.Title = def.Term
+		}
+	}
+	return l
+}
+
+// fileName names the page for an ID. Characters that are not safe in file
+// names and URLs are escaped as _XX per UTF-8 byte, with _ itself escaped, so
+// distinct IDs always get distinct names.
+func fileName(id string) string {
+	var b strings.Builder
+	for i := 0; i < len(id); i++ {
+		switch c := id[i]; {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '.':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "_%02X", c)
+		}
+	}
+	return b.String() + ".html"
+}
+
+func mustRead(name string) string {
+	data, err := templates.ReadFile(name)
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
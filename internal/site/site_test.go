@@ -0,0 +1,136 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+	"github.com/ethanolivertroy/fedramp-tui/internal/store"
+)
+
+func testStore() *store.Store {
+	fetched := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	return store.New(store.Contents{
+		Documents: []model.Document{
+			{Code: "FRD", Name: "Definitions", DefinitionCount: 1, FetchedAt: fetched},
+			{Code: "VDR", Name: "Vulnerability Detection and Response", RequirementCount: 2, Purpose: "Find vulnerabilities.", FetchedAt: fetched.Add(time.Hour)},
+			{Code: "KSI", Name: "Key Security Indicators", IndicatorCount: 1, FetchedAt: fetched},
+		},
+		Requirements: []model.Requirement{
+			{ID: "FRR-VDR-01", DocumentCode: "VDR", Category: model.Category{ID: "base", Name: "Base"}, Name: "Detect", Statement: "Providers MUST detect each Vulnerability <now>.", PrimaryKeyWord: "MUST", Children: []string{"FRR-VDR-02"}},
+			{ID: "FRR-VDR-02", DocumentCode: "VDR", Category: model.Category{ID: "base", Name: "Base"}, Name: "Report", Statement: "Providers SHOULD report per FRR-VDR-01.", PrimaryKeyWord: "SHOULD", ParentID: "FRR-VDR-01"},
+		},
+		Definitions: []model.Definition{
+			{ID: "FRD-ALL-01", Term: "Vulnerability", Text: "A weakness in an information system."},
+		},
+		Indicators: []model.Indicator{
+			{ID: "KSI-IAM-01", ThemeCode: "IAM", ThemeName: "Identity and Access Management", Name: "MFA", Statement: "Enforce MFA.", Controls: []model.Control{{ControlID: "ia-2.1", Title: "Multi-factor Authentication"}}},
+		},
+	})
+}
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	s := New(testStore(), "FedRAMP/docs@v1.2.0")
+	n, err := s.Write(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// An index, 3 documents, 2 requirements, 1 indicator and 1 definition
+	if n != 8 {
+		t.Errorf("Expected 8 pages, got %d", n)
+	}
+
+	read := func(path string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(dir, path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"index.html", []string{
+			`<a href="documents/VDR.html">VDR</a>`,
+			`<script src="search-index.js"></script>`,
+			"Source: FedRAMP/docs@v1.2.0, fetched 2026-03-01",
+		}},
+		{"documents/VDR.html", []string{
+			`<div class="item" id="FRR-VDR-01">`,
+			`<a href="../requirements/FRR-VDR-01.html">FRR-VDR-01</a>`,
+			`<a class="anchor" href="#FRR-VDR-01"`,
+			`<h3 id="base">Base</h3>`,
+		}},
+		{"requirements/FRR-VDR-01.html", []string{
+			`<span class="badge must">MUST</span>`,
+			`detect each <a href="../definitions/FRD-ALL-01.html" title="Vulnerability">Vulnerability</a> &lt;now&gt;.`,
+			`<a href="../requirements/FRR-VDR-02.html">FRR-VDR-02</a> Report`,
+			`<a href="../documents/VDR.html#FRR-VDR-01">View in VDR</a>`,
+			`action="../index.html"`,
+			"Source: FedRAMP/docs@v1.2.0",
+		}},
+		{"requirements/FRR-VDR-02.html", []string{
+			`report per <a href="../requirements/FRR-VDR-01.html" title="Detect">FRR-VDR-01</a>.`,
+		}},
+		{"definitions/FRD-ALL-01.html", []string{
+			"<h2 id=\"used-by\">Used by</h2>",
+			`<a href="../requirements/FRR-VDR-01.html">FRR-VDR-01</a> Detect`,
+		}},
+		{"indicators/KSI-IAM-01.html", []string{
+			`<strong>IA-2(1)</strong> Multi-factor Authentication`,
+			`<a href="../documents/KSI.html#IAM">IAM: Identity and Access Management</a>`,
+		}},
+	}
+	for _, tt := range tests {
+		page := read(tt.path)
+		for _, want := range tt.want {
+			if !strings.Contains(page, want) {
+				t.Errorf("Expected %s to contain %s", tt.path, want)
+			}
+		}
+		for _, external := range []string{"http://", "https://", "<link"} {
+			if strings.Contains(page, external) {
+				t.Errorf("Expected %s to have no external assets, found %s", tt.path, external)
+			}
+		}
+	}
+
+	index := read("search-index.js")
+	if !strings.HasPrefix(index, "window.FEDRAMP_INDEX = [") || !strings.Contains(index, `"url":"requirements/FRR-VDR-02.html"`) {
+		t.Errorf("Unexpected search index:\n%s", index)
+	}
+	if !strings.Contains(read("search.js"), "FEDRAMP_INDEX") {
+		t.Error("Expected the search script")
+	}
+}
+
+func TestFileName(t *testing.T) {
+	for id, want := range map[string]string{
+		"FRR-VDR-01":   "FRR-VDR-01.html",
+		"FRR-VDR-01.2": "FRR-VDR-01.2.html",
+		"IA-2(1)":      "IA-2_281_29.html",
+		"../x":         ".._2Fx.html",
+		"A/B":          "A_2FB.html",
+		"A_2FB":        "A_5F2FB.html",
+	} {
+		if got := fileName(id); got != want {
+			t.Errorf("fileName(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
+
+func TestWriteRejectsCollidingPages(t *testing.T) {
+	s := New(store.New(store.Contents{
+		Requirements: []model.Requirement{{ID: "FRR-X-a"}, {ID: "FRR-X-A"}},
+	}), "test")
+	if _, err := s.Write(t.TempDir()); err == nil || !strings.Contains(err.Error(), "FRR-X-a and FRR-X-A") {
+		t.Errorf("Expected IDs differing only in case to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+// Client-side search over window.FEDRAMP_INDEX, loaded from search-index.js.
+// Every word of the query must appear in an item's ID, title or text.
+(function () {
+  var params = new URLSearchParams(window.location.search);
+  var query = (params.get("q") || "").trim();
+  var box = document.querySelector("input[name=q]");
+  if (box) box.value = query;
+  if (!query || !window.FEDRAMP_INDEX) return;
+
+  var words = query.toLowerCase().split(/\s+/);
+  var matches = window.FEDRAMP_INDEX.filter(function (item) {
+    var haystack = (item.id + " " + item.title + " " + item.text).toLowerCase();
+    return words.every(function (w) { return haystack.indexOf(w) !== -1; });
+  });
+  // IDs and titles that match outrank matches in the text
+  var rank = function (item) {
+    var head = (item.id + " " + item.title).toLowerCase();
+    return words.every(function (w) { return head.indexOf(w) !== -1; }) ? 0 : 1;
+  };
+  matches.sort(function (a, b) { return rank(a) - rank(b); });
+
+  var list = document.getElementById("search-results");
+  matches.slice(0, 200).forEach(function (item) {
+    var li = document.createElement("li");
+    var a = document.createElement("a");
+    a.href = item.url;
+    a.textContent = item.id;
+    li.appendChild(a);
+    li.appendChild(document.createTextNode(" " + item.title));
+    var kind = document.createElement("span");
+    kind.className = "kind";
+    kind.textContent = item.kind;
+    li.appendChild(kind);
+    list.appendChild(li);
+  });
+  document.getElementById("search-summary").textContent =
+    matches.length + (matches.length === 1 ? " match" : " matches") + " for “" + query + "”" +
+    (matches.length > 200 ? ", showing the first 200" : "");
+  document.getElementById("search").hidden = false;
+})();
@@ -0,0 +1,114 @@
+//go:build ignore
+
+// gen downloads every FRMR document into data/ and writes data/manifest.json.
+// Run it with `go generate ./internal/snapshot` and commit data/.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/api"
+)
+
+const commitsURL = "https://api.github.com/repos/FedRAMP/docs/commits/"
+
+func main() {
+	ref := flag.String("ref", "main", "FedRAMP/docs branch, tag or commit to snapshot")
+	flag.Parse()
+
+	if err := run(*ref); err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ref string) error {
+	client := &http.Client{Timeout: 60 * time.Second}
+
+	// Resolve the ref to a commit so every file comes from the same tree
+	sha, err := resolveCommit(client, ref)
+	if err != nil {
+		return fmt.Errorf("resolving %s: %w", ref, err)
+	}
+
+	source := api.NewHTTPSource("https://raw.githubusercontent.com/FedRAMP/docs/" + sha)
+
+	// Snapshot whatever FRMR documents the tree contains, not just the known ones
+	listed, err := source.List(context.Background())
+	if err != nil {
+		return fmt.Errorf("listing documents: %w", err)
+	}
+
+	// Documents removed upstream must not linger in the checked-in snapshot
+	old, err := filepath.Glob(filepath.Join("data", "FRMR.*.json"))
+	if err != nil {
+		return err
+	}
+	for _, name := range old {
+		if err := os.Remove(name); err != nil {
+			return err
+		}
+	}
+
+	var filenames []string
+	for _, filename := range listed {
+		if _, ok := api.ParseDocumentFilename(filename); !ok {
+			continue
+		}
+		data, err := source.Fetch(context.Background(), filename)
+		if err != nil {
+			return fmt.Errorf("fetching %s: %w", filename, err)
+		}
+		if err := os.WriteFile(filepath.Join("data", filename), data, 0644); err != nil {
+			return err
+		}
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	now := time.Now().UTC()
+	manifest := map[string]any{
+		"release":    fmt.Sprintf("%s@%s (%s)", ref, sha[:7], now.Format("2006-01-02")),
+		"ref":        sha,
+		"fetched_at": now.Format(time.RFC3339),
+		"files":      filenames,
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join("data", "manifest.json"), append(data, '\n'), 0644)
+}
+
+func resolveCommit(client *http.Client, ref string) (string, error) {
+	resp, err := client.Get(commitsURL + ref)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("HTTP %d: %s", resp.StatusCode, body)
+	}
+
+	var commit struct {
+		SHA string `json:"sha"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&commit); err != nil {
+		return "", err
+	}
+	if len(commit.SHA) < 7 {
+		return "", fmt.Errorf("unexpected commit SHA %q", commit.SHA)
+	}
+	return commit.SHA, nil
+}
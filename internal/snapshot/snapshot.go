@@ -0,0 +1,56 @@
+// Package snapshot embeds an offline copy of the FRMR documents so the TUI
+// can run in air-gapped environments. The data directory is checked in, so
+// every build carries it; refresh it with `go generate ./internal/snapshot`
+// and commit the result.
+package snapshot
+
+//go:generate go run gen.go
+
+import (
+	"embed"
+	"encoding/json"
+	"path"
+	"sync"
+)
+
+//go:embed data
+var files embed.FS
+
+// Manifest describes the embedded snapshot
+type Manifest struct {
+	Release   string   `json:"release"`    // Human readable label, e.g. "main@1a2b3c4 (2025-06-01)"
+	Ref       string   `json:"ref"`        // Upstream commit SHA the snapshot was taken from
+	FetchedAt string   `json:"fetched_at"` // RFC 3339 timestamp of when the snapshot was generated
+	Files     []string `json:"files"`      // Document filenames included in the snapshot
+}
+
+var (
+	loadOnce sync.Once
+	manifest Manifest
+)
+
+// Load returns the snapshot manifest
+func Load() Manifest {
+	loadOnce.Do(func() {
+		data, err := files.ReadFile("data/manifest.json")
+		if err != nil {
+			return
+		}
+		_ = json.Unmarshal(data, &manifest)
+	})
+	return manifest
+}
+
+// Available returns true if the binary was built with snapshot documents
+func Available() bool {
+	return len(Load().Files) > 0
+}
+
+// Get returns the embedded copy of a document file
+func Get(filename string) ([]byte, bool) {
+	data, err := files.ReadFile(path.Join("data", path.Base(filename)))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
@@ -0,0 +1,32 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestManifestFilesAreEmbedded(t *testing.T) {
+	m := Load()
+	if len(m.Files) == 0 {
+		t.Skip("The snapshot is empty; run `go generate ./internal/snapshot` and commit internal/snapshot/data")
+	}
+	for _, name := range m.Files {
+		data, ok := Get(name)
+		if !ok {
+			t.Errorf("Manifest lists %s but it is not embedded", name)
+			continue
+		}
+		if !json.Valid(data) {
+			t.Errorf("Embedded %s is not valid JSON", name)
+		}
+	}
+	if m.Release == "" || m.Ref == "" {
+		t.Error("Expected a release label for a populated snapshot")
+	}
+}
+
+func TestGetRejectsPaths(t *testing.T) {
+	if _, ok := Get("../snapshot.go"); ok {
+		t.Error("Expected Get to only serve files from the data directory")
+	}
+}
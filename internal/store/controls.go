@@ -0,0 +1,85 @@
+package store
+
+import (
+	"slices"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/catalog"
+)
+
+// MappedControl is an SP 800-53 control that indicators map to
+type MappedControl struct {
+	ID         string   `json:"id"`         // Normalized label, e.g. "AC-2(1)"
+	Title      string   `json:"title"`      // As given by the first indicator that maps it
+	Indicators []string `json:"indicators"` // IDs of the mapping indicators, in document order
+}
+
+// ControlFamily groups the controls that indicators map to by SP 800-53 family
+type ControlFamily struct {
+	ID         string // e.g. "AC"
+	Name       string // e.g. "Access Control", if the family is known
+	Controls   []MappedControl
+	Indicators int // Distinct indicators that map to a control in the family
+}
+
+// indexControls builds the control to indicator index and groups the mapped
+// controls by family. Control IDs are normalized so that "ac-2.1" and
+// "AC-2(1)" are the same control.
+func (s *Store) indexControls() {
+	titles := make(map[string]string)
+	for i, ind := range s.indicators {
+		for _, ctrl := range ind.Controls {
+			id := catalog.LabelFor(ctrl.ControlID)
+			if id == "" {
+				continue
+			}
+			if titles[id] == "" {
+				titles[id] = ctrl.Title
+			}
+			if n := len(s.controlIndex[id]); n > 0 && s.controlIndex[id][n-1] == i {
+				continue // Mapped twice by the same indicator
+			}
+			s.controlIndex[id] = append(s.controlIndex[id], i)
+		}
+	}
+
+	ids := make([]string, 0, len(s.controlIndex))
+	for id := range s.controlIndex {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, catalog.Compare)
+
+	s.mappedIndex = make(map[string][2]int, len(ids))
+	var indicators map[int]bool
+	for _, id := range ids {
+		family := catalog.Family(id)
+		if len(s.families) == 0 || s.families[len(s.families)-1].ID != family {
+			s.families = append(s.families, ControlFamily{ID: family, Name: catalog.Families[family]})
+			indicators = make(map[int]bool)
+		}
+		f := &s.families[len(s.families)-1]
+
+		mapped := MappedControl{ID: id, Title: titles[id]}
+		for _, i := range s.controlIndex[id] {
+			mapped.Indicators = append(mapped.Indicators, s.indicators[i].ID)
+			indicators[i] = true
+		}
+		f.Indicators = len(indicators)
+		s.mappedIndex[id] = [2]int{len(s.families) - 1, len(f.Controls)}
+		f.Controls = append(f.Controls, mapped)
+	}
+}
+
+// ControlFamilies returns the controls that indicators map to, grouped by
+// family, in family and control order
+func (s *Store) ControlFamilies() []ControlFamily {
+	return s.families
+}
+
+// MappedControl looks up a control that indicators map to, by label or OSCAL ID
+func (s *Store) MappedControl(id string) (MappedControl, bool) {
+	i, ok := s.mappedIndex[catalog.LabelFor(id)]
+	if !ok {
+		return MappedControl{}, false
+	}
+	return s.families[i[0]].Controls[i[1]], true
+}
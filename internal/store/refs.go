@@ -0,0 +1,171 @@
+package store
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// RefKind is the kind of item a cross-reference points to
+type RefKind int
+
+const (
+	RefRequirement RefKind = iota
+	RefIndicator
+	RefDefinition
+	RefControl // An SP 800-53 control; never found in text, only linked explicitly
+)
+
+// String returns the lowercase name of the kind, e.g. "requirement"
+func (k RefKind) String() string {
+	switch k {
+	case RefRequirement:
+		return "requirement"
+	case RefIndicator:
+		return "indicator"
+	case RefDefinition:
+		return "definition"
+	case RefControl:
+		return "control"
+	}
+	return "unknown"
+}
+
+// Reference is a mention of a known requirement, indicator or defined term
+// within a piece of text
+type Reference struct {
+	Kind  RefKind
+	ID    string // ID of the referenced item
+	Start int    // Byte offsets of the mention in the text
+	End   int
+}
+
+// idPattern matches anything shaped like an FRMR ID, e.g. VDR-CSO-DET or
+// KSI-IAM-01, including the suffixes of sub-requirements such as
+// FRR-VDR-01.2 or FRR-VDR-01-a
+var idPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9]*(?:-[A-Z0-9]+)+(?:\.[0-9]+|-[a-z][a-z0-9]*)*\b`)
+
+// idSuffix matches the last sub-requirement suffix of an ID
+var idSuffix = regexp.MustCompile(`(?:\.[0-9]+|-[a-z][a-z0-9]*)$`)
+
+// termPattern builds a case-insensitive pattern matching any indexed term or
+// alternative as whole words. Longer terms are tried first so that "Federal
+// Agency" wins over "Agency"; whitespace inside a term matches any run of
+// whitespace so terms still match across wrapped lines.
+func termPattern(terms []string) *regexp.Regexp {
+	if len(terms) == 0 {
+		return nil
+	}
+	terms = slices.Clone(terms)
+	slices.SortFunc(terms, func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(b), len(a)), strings.Compare(a, b))
+	})
+	alts := make([]string, len(terms))
+	for i, term := range terms {
+		words := strings.Fields(term)
+		for j, w := range words {
+			words[j] = regexp.QuoteMeta(w)
+		}
+		alts[i] = strings.Join(words, `\s+`)
+	}
+	return regexp.MustCompile(`(?i)\b(?:` + strings.Join(alts, "|") + `)\b`)
+}
+
+// References finds the known IDs and defined terms mentioned in text, in the
+// order they appear. IDs take precedence over terms they overlap.
+func (s *Store) References(text string) []Reference {
+	var refs []Reference
+	for _, loc := range idPattern.FindAllStringIndex(text, -1) {
+		// Fall back to the closest known parent of an unknown sub-requirement,
+		// e.g. when a suffix is followed by ordinary hyphenated words
+		id := text[loc[0]:loc[1]]
+		kind, ok := s.kindOf(id)
+		for !ok && idSuffix.MatchString(id) {
+			id = idSuffix.ReplaceAllString(id, "")
+			kind, ok = s.kindOf(id)
+		}
+		if !ok {
+			continue
+		}
+		refs = append(refs, Reference{Kind: kind, ID: id, Start: loc[0], End: loc[0] + len(id)})
+	}
+
+	ids := len(refs)
+	for _, ref := range s.termReferences(text) {
+		overlaps := slices.ContainsFunc(refs[:ids], func(r Reference) bool {
+			return ref.Start < r.End && r.Start < ref.End
+		})
+		if !overlaps {
+			refs = append(refs, ref)
+		}
+	}
+
+	slices.SortFunc(refs, func(a, b Reference) int { return a.Start - b.Start })
+	return refs
+}
+
+// kindOf reports which kind of item an ID refers to, if any
+func (s *Store) kindOf(id string) (RefKind, bool) {
+	if _, ok := s.requirementIndex[id]; ok {
+		return RefRequirement, true
+	}
+	if _, ok := s.indicatorIndex[id]; ok {
+		return RefIndicator, true
+	}
+	if _, ok := s.definitionIndex[id]; ok {
+		return RefDefinition, true
+	}
+	return 0, false
+}
+
+// termReferences finds the defined terms and alternatives mentioned in text
+func (s *Store) termReferences(text string) []Reference {
+	if s.terms == nil {
+		return nil
+	}
+	var refs []Reference
+	for _, loc := range s.terms.FindAllStringIndex(text, -1) {
+		i, ok := s.termIndex[normalizeTerm(text[loc[0]:loc[1]])]
+		if !ok {
+			continue
+		}
+		refs = append(refs, Reference{Kind: RefDefinition, ID: s.definitions[i].ID, Start: loc[0], End: loc[1]})
+	}
+	return refs
+}
+
+// Usage is a requirement or indicator that mentions a defined term
+type Usage struct {
+	Kind RefKind
+	ID   string
+}
+
+// indexUsages records, for each definition, the requirements and indicators
+// whose name, statement or notes mention its term or an alternative
+func (s *Store) indexUsages() {
+	s.usages = make(map[string][]Usage)
+	record := func(kind RefKind, id string, texts ...string) {
+		seen := make(map[string]bool)
+		for _, text := range texts {
+			for _, ref := range s.termReferences(text) {
+				if !seen[ref.ID] {
+					seen[ref.ID] = true
+					s.usages[ref.ID] = append(s.usages[ref.ID], Usage{Kind: kind, ID: id})
+				}
+			}
+		}
+	}
+	for _, r := range s.requirements {
+		record(RefRequirement, r.ID, append([]string{r.Name, r.Statement, r.Note}, r.Following...)...)
+	}
+	for _, ind := range s.indicators {
+		record(RefIndicator, ind.ID, ind.Name, ind.Statement, ind.Note)
+	}
+}
+
+// Usages returns the requirements and indicators that use a definition's
+// term, requirements first, each in document order
+func (s *Store) Usages(definitionID string) []Usage {
+	return s.usages[definitionID]
+}
@@ -0,0 +1,38 @@
+package store
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+)
+
+func TestReferenceSubRequirements(t *testing.T) {
+	s := New(Contents{
+		Requirements: []model.Requirement{
+			{ID: "FRR-VDR-01"},
+			{ID: "FRR-VDR-01.2", ParentID: "FRR-VDR-01"},
+			{ID: "FRR-VDR-01-a", ParentID: "FRR-VDR-01"},
+		},
+	})
+
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"See FRR-VDR-01.2.", []string{"FRR-VDR-01.2:FRR-VDR-01.2"}},
+		{"See FRR-VDR-01-a, then FRR-VDR-01.", []string{"FRR-VDR-01-a:FRR-VDR-01-a", "FRR-VDR-01:FRR-VDR-01"}},
+		// Unknown sub-requirements and hyphenated words still link the parent
+		{"FRR-VDR-01.9 and FRR-VDR-01-based scans", []string{"FRR-VDR-01:FRR-VDR-01", "FRR-VDR-01:FRR-VDR-01"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, ref := range s.References(tt.text) {
+			got = append(got, fmt.Sprintf("%s:%s", ref.ID, tt.text[ref.Start:ref.End]))
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("References(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,265 @@
+// Package store indexes parsed FRMR documents for lookup by ID, document,
+// control and term
+package store
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/api"
+	"github.com/ethanolivertroy/fedramp-tui/internal/catalog"
+	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+)
+
+// Contents is the data a store is built from, in display order
+type Contents struct {
+	Documents    []model.Document
+	Requirements []model.Requirement
+	Definitions  []model.Definition
+	Indicators   []model.Indicator
+}
+
+// Store holds the parsed documents and indexes over them. It is read-only
+// once built, so it can be shared freely.
+type Store struct {
+	documents    []model.Document
+	requirements []model.Requirement
+	definitions  []model.Definition
+	indicators   []model.Indicator
+	rfcs         []model.RFC
+
+	documentIndex    map[string]int     // Document code
+	requirementIndex map[string]int     // Requirement ID
+	definitionIndex  map[string]int     // Definition ID
+	indicatorIndex   map[string]int     // Indicator ID
+	documentReqs     map[string][]int   // Document code to its requirements
+	controlIndex     map[string][]int   // Control label to the indicators that map to it
+	families         []ControlFamily    // Mapped controls by family, in control order
+	mappedIndex      map[string][2]int  // Control label to its family and position within it
+	termIndex        map[string]int     // Lowercased term or alternative to its definition
+	terms            *regexp.Regexp     // Matches any indexed term, for finding references
+	usages           map[string][]Usage // Definition ID to the items that use its term
+}
+
+// New indexes the given contents
+func New(c Contents) *Store {
+	s := &Store{
+		documents:        c.Documents,
+		requirements:     c.Requirements,
+		definitions:      c.Definitions,
+		indicators:       c.Indicators,
+		rfcs:             model.CollectRFCs(c.Documents),
+		documentIndex:    make(map[string]int, len(c.Documents)),
+		requirementIndex: make(map[string]int, len(c.Requirements)),
+		definitionIndex:  make(map[string]int, len(c.Definitions)),
+		indicatorIndex:   make(map[string]int, len(c.Indicators)),
+		documentReqs:     make(map[string][]int),
+		controlIndex:     make(map[string][]int),
+		termIndex:        make(map[string]int),
+	}
+
+	// The first occurrence of a key wins, matching a linear scan
+	for i, d := range s.documents {
+		setOnce(s.documentIndex, d.Code, i)
+	}
+	for i, r := range s.requirements {
+		setOnce(s.requirementIndex, r.ID, i)
+		s.documentReqs[r.DocumentCode] = append(s.documentReqs[r.DocumentCode], i)
+	}
+	for i, d := range s.definitions {
+		setOnce(s.definitionIndex, d.ID, i)
+		setOnce(s.termIndex, normalizeTerm(d.Term), i)
+		for _, alt := range d.Alts {
+			setOnce(s.termIndex, normalizeTerm(alt), i)
+		}
+	}
+	terms := make([]string, 0, len(s.termIndex))
+	for term := range s.termIndex {
+		terms = append(terms, term)
+	}
+	s.terms = termPattern(terms)
+
+	for i, ind := range s.indicators {
+		setOnce(s.indicatorIndex, ind.ID, i)
+	}
+	s.indexControls()
+	s.indexUsages()
+	return s
+}
+
+// FromResults builds a store from per-document load results, in the order
+// given. Document counts and load status are filled in from each result.
+func FromResults(results []api.DocumentResult) *Store {
+	var c Contents
+	c.Documents = make([]model.Document, 0, len(results))
+	for _, res := range results {
+		doc := res.Document
+
+		c.Requirements = append(c.Requirements, res.Requirements...)
+		c.Definitions = append(c.Definitions, res.Definitions...)
+		c.Indicators = append(c.Indicators, res.Indicators...)
+
+		doc.RequirementCount = len(res.Requirements)
+		doc.IndicatorCount = len(res.Indicators)
+		doc.DefinitionCount = len(res.Definitions)
+
+		doc.Stale = res.Stale()
+		doc.FetchedAt = res.FetchedAt
+		c.Documents = append(c.Documents, doc)
+	}
+	return New(c)
+}
+
+func setOnce(index map[string]int, key string, i int) {
+	if key == "" {
+		return
+	}
+	if _, ok := index[key]; !ok {
+		index[key] = i
+	}
+}
+
+// normalizeTerm folds case and whitespace so terms match however they are written
+func normalizeTerm(term string) string {
+	return strings.ToLower(strings.Join(strings.Fields(term), " "))
+}
+
+// Documents returns all documents in display order
+func (s *Store) Documents() []model.Document { return s.documents }
+
+// Requirements returns all requirements in document order
+func (s *Store) Requirements() []model.Requirement { return s.requirements }
+
+// Definitions returns all definitions in document order
+func (s *Store) Definitions() []model.Definition { return s.definitions }
+
+// Indicators returns all indicators in document order
+func (s *Store) Indicators() []model.Indicator { return s.indicators }
+
+// RFCs returns every RFC referenced by a document release, newest first
+func (s *Store) RFCs() []model.RFC { return s.rfcs }
+
+// Document looks up a document by code
+func (s *Store) Document(code string) (model.Document, bool) {
+	i, ok := s.documentIndex[code]
+	if !ok {
+		return model.Document{}, false
+	}
+	return s.documents[i], true
+}
+
+// Requirement looks up a requirement by ID
+func (s *Store) Requirement(id string) (model.Requirement, bool) {
+	i, ok := s.requirementIndex[id]
+	if !ok {
+		return model.Requirement{}, false
+	}
+	return s.requirements[i], true
+}
+
+// Definition looks up a definition by ID
+func (s *Store) Definition(id string) (model.Definition, bool) {
+	i, ok := s.definitionIndex[id]
+	if !ok {
+		return model.Definition{}, false
+	}
+	return s.definitions[i], true
+}
+
+// Indicator looks up an indicator by ID
+func (s *Store) Indicator(id string) (model.Indicator, bool) {
+	i, ok := s.indicatorIndex[id]
+	if !ok {
+		return model.Indicator{}, false
+	}
+	return s.indicators[i], true
+}
+
+// DocumentRequirements returns the requirements of a document in document order
+func (s *Store) DocumentRequirements(code string) []model.Requirement {
+	return collect(s.requirements, s.documentReqs[code])
+}
+
+// Filter selects requirements. Empty fields match every requirement; the
+// keyword and affected party are matched ignoring case.
+type Filter struct {
+	Document string // Document code
+	Category string // Category ID within the document
+	Keyword  string // Primary keyword, e.g. "MUST"
+	Affects  string // Affected party, e.g. "Providers"
+}
+
+// Match returns true if the requirement passes the filter
+func (f Filter) Match(r model.Requirement) bool {
+	if f.Document != "" && !strings.EqualFold(r.DocumentCode, f.Document) {
+		return false
+	}
+	if f.Category != "" && r.Category.ID != f.Category {
+		return false
+	}
+	if f.Keyword != "" && !strings.EqualFold(r.PrimaryKeyWord, f.Keyword) {
+		return false
+	}
+	if f.Affects != "" && !slices.ContainsFunc(r.Affects, func(a string) bool { return strings.EqualFold(a, f.Affects) }) {
+		return false
+	}
+	return true
+}
+
+// FilterRequirements returns the requirements that pass the filter, in
+// document order
+func (s *Store) FilterRequirements(f Filter) []model.Requirement {
+	all := s.requirements
+	if f.Document != "" {
+		all = s.DocumentRequirements(strings.ToUpper(f.Document))
+	}
+	var out []model.Requirement
+	for _, r := range all {
+		if f.Match(r) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// IndicatorsForControl returns the indicators that map to a control, in
+// document order
+func (s *Store) IndicatorsForControl(controlID string) []model.Indicator {
+	return collect(s.indicators, s.controlIndex[catalog.LabelFor(controlID)])
+}
+
+// DefinitionForTerm looks up the definition of a term or one of its
+// alternatives, ignoring case
+func (s *Store) DefinitionForTerm(term string) (model.Definition, bool) {
+	i, ok := s.termIndex[normalizeTerm(term)]
+	if !ok {
+		return model.Definition{}, false
+	}
+	return s.definitions[i], true
+}
+
+// Categories returns the requirement categories of a document in the order
+// they first appear
+func (s *Store) Categories(code string) []model.Category {
+	var categories []model.Category
+	seen := make(map[string]bool)
+	for _, r := range s.DocumentRequirements(code) {
+		if !seen[r.Category.ID] {
+			seen[r.Category.ID] = true
+			categories = append(categories, r.Category)
+		}
+	}
+	return categories
+}
+
+func collect[T any](items []T, indexes []int) []T {
+	if len(indexes) == 0 {
+		return nil
+	}
+	out := make([]T, len(indexes))
+	for i, idx := range indexes {
+		out[i] = items[idx]
+	}
+	return out
+}
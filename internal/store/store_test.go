@@ -0,0 +1,266 @@
+package store
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/api"
+	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+)
+
+func testStore() *Store {
+	return New(Contents{
+		Documents: []model.Document{
+			{Code: "FRD", Name: "FedRAMP Definitions"},
+			{Code: "VDR", Name: "Vulnerability Detection and Response"},
+		},
+		Requirements: []model.Requirement{
+			{ID: "VDR-CSO-DET", DocumentCode: "VDR", Category: model.Category{ID: "CSO"}},
+			{ID: "UCM-CSO-MUC", DocumentCode: "UCM", Category: model.Category{ID: "CSO"}},
+			{ID: "VDR-FRP-ARP", DocumentCode: "VDR", Category: model.Category{ID: "FRP"}},
+			{ID: "VDR-CSO-RES", DocumentCode: "VDR", Category: model.Category{ID: "CSO"}},
+		},
+		Definitions: []model.Definition{
+			{ID: "FRD-ALL-01", Term: "Agency", Alts: []string{"Federal Agency"}},
+			{ID: "FRD-ALL-02", Term: "Vulnerability  Detection"},
+			{ID: "FRD-ALL-03", Term: "agency"},
+		},
+		Indicators: []model.Indicator{
+			{ID: "KSI-IAM-01", Controls: []model.Control{{ControlID: "ac-2"}, {ControlID: "AC-2"}, {ControlID: "ia-2"}}},
+			{ID: "KSI-CNA-01", Controls: []model.Control{{ControlID: "sc-7"}}},
+			{ID: "KSI-IAM-02", Controls: []model.Control{{ControlID: "AC-2"}}},
+		},
+	})
+}
+
+func TestLookupByID(t *testing.T) {
+	s := testStore()
+
+	if doc, ok := s.Document("VDR"); !ok || doc.Name != "Vulnerability Detection and Response" {
+		t.Errorf("Document(VDR) = %+v, %v", doc, ok)
+	}
+	if r, ok := s.Requirement("VDR-FRP-ARP"); !ok || r.Category.ID != "FRP" {
+		t.Errorf("Requirement(VDR-FRP-ARP) = %+v, %v", r, ok)
+	}
+	if d, ok := s.Definition("FRD-ALL-02"); !ok || d.Term != "Vulnerability  Detection" {
+		t.Errorf("Definition(FRD-ALL-02) = %+v, %v", d, ok)
+	}
+	if ind, ok := s.Indicator("KSI-CNA-01"); !ok || ind.Controls[0].ControlID != "sc-7" {
+		t.Errorf("Indicator(KSI-CNA-01) = %+v, %v", ind, ok)
+	}
+
+	for _, missing := range []func() bool{
+		func() bool { _, ok := s.Document("XYZ"); return ok },
+		func() bool { _, ok := s.Requirement("VDR-NOPE"); return ok },
+		func() bool { _, ok := s.Definition(""); return ok },
+		func() bool { _, ok := s.Indicator("KSI-NOPE"); return ok },
+	} {
+		if missing() {
+			t.Error("Expected lookup of a missing ID to fail")
+		}
+	}
+}
+
+func TestDocumentRequirementsAndCategories(t *testing.T) {
+	s := testStore()
+
+	var ids []string
+	for _, r := range s.DocumentRequirements("VDR") {
+		ids = append(ids, r.ID)
+	}
+	if want := []string{"VDR-CSO-DET", "VDR-FRP-ARP", "VDR-CSO-RES"}; !slices.Equal(ids, want) {
+		t.Errorf("Expected %v, got %v", want, ids)
+	}
+
+	var categories []string
+	for _, c := range s.Categories("VDR") {
+		categories = append(categories, c.ID)
+	}
+	if want := []string{"CSO", "FRP"}; !slices.Equal(categories, want) {
+		t.Errorf("Expected categories %v, got %v", want, categories)
+	}
+}
+
+func TestFilterRequirements(t *testing.T) {
+	s := New(Contents{Requirements: []model.Requirement{
+		{ID: "VDR-1", DocumentCode: "VDR", PrimaryKeyWord: "MUST", Affects: []string{"Providers"}},
+		{ID: "VDR-2", DocumentCode: "VDR", PrimaryKeyWord: "SHOULD", Affects: []string{"Providers", "Agencies"}},
+		{ID: "UCM-1", DocumentCode: "UCM", PrimaryKeyWord: "MUST", Affects: []string{"Agencies"}},
+	}})
+
+	tests := []struct {
+		filter Filter
+		want   []string
+	}{
+		{Filter{}, []string{"VDR-1", "VDR-2", "UCM-1"}},
+		{Filter{Document: "vdr"}, []string{"VDR-1", "VDR-2"}},
+		{Filter{Keyword: "must"}, []string{"VDR-1", "UCM-1"}},
+		{Filter{Document: "VDR", Affects: "agencies"}, []string{"VDR-2"}},
+		{Filter{Affects: "Assessors"}, nil},
+	}
+	for _, tt := range tests {
+		var ids []string
+		for _, r := range s.FilterRequirements(tt.filter) {
+			ids = append(ids, r.ID)
+		}
+		if !slices.Equal(ids, tt.want) {
+			t.Errorf("%+v: expected %v, got %v", tt.filter, tt.want, ids)
+		}
+	}
+}
+
+func TestIndicatorsForControl(t *testing.T) {
+	s := testStore()
+
+	var ids []string
+	for _, ind := range s.IndicatorsForControl("Ac-2 ") {
+		ids = append(ids, ind.ID)
+	}
+	// Each indicator is listed once even if it maps the control twice
+	if want := []string{"KSI-IAM-01", "KSI-IAM-02"}; !slices.Equal(ids, want) {
+		t.Errorf("Expected %v, got %v", want, ids)
+	}
+	if got := s.IndicatorsForControl("AU-2"); got != nil {
+		t.Errorf("Expected no indicators for an unmapped control, got %v", got)
+	}
+}
+
+func TestControlFamilies(t *testing.T) {
+	s := New(Contents{Indicators: []model.Indicator{
+		{ID: "KSI-IAM-01", Controls: []model.Control{{ControlID: "ia-2"}, {ControlID: "ac-2.1", Title: "Automated Account Management"}, {ControlID: "ac-10"}}},
+		{ID: "KSI-IAM-02", Controls: []model.Control{{ControlID: "AC-2(1)"}, {ControlID: "AC-2"}}},
+	}})
+
+	families := s.ControlFamilies()
+	if len(families) != 2 || families[0].ID != "AC" || families[1].ID != "IA" {
+		t.Fatalf("Expected the AC and IA families, got %+v", families)
+	}
+	ac := families[0]
+	if ac.Name != "Access Control" || ac.Indicators != 2 {
+		t.Errorf("Expected Access Control mapped by 2 indicators, got %q with %d", ac.Name, ac.Indicators)
+	}
+	var ids []string
+	for _, c := range ac.Controls {
+		ids = append(ids, c.ID)
+	}
+	if want := []string{"AC-2", "AC-2(1)", "AC-10"}; !slices.Equal(ids, want) {
+		t.Errorf("Expected %v, got %v", want, ids)
+	}
+
+	// ac-2.1 and AC-2(1) are the same control
+	c, ok := s.MappedControl("ac-2.1")
+	if !ok || c.Title != "Automated Account Management" || !slices.Equal(c.Indicators, []string{"KSI-IAM-01", "KSI-IAM-02"}) {
+		t.Errorf("Expected AC-2(1) mapped by both indicators, got %+v", c)
+	}
+	if _, ok := s.MappedControl("AU-2"); ok {
+		t.Error("Expected AU-2 not to be mapped")
+	}
+}
+
+func TestDefinitionForTerm(t *testing.T) {
+	s := testStore()
+
+	tests := []struct {
+		term string
+		id   string
+	}{
+		{"agency", "FRD-ALL-01"}, // The first definition of a term wins
+		{"FEDERAL AGENCY", "FRD-ALL-01"},
+		{"vulnerability detection", "FRD-ALL-02"},
+	}
+	for _, tt := range tests {
+		if d, ok := s.DefinitionForTerm(tt.term); !ok || d.ID != tt.id {
+			t.Errorf("DefinitionForTerm(%q) = %s, %v; want %s", tt.term, d.ID, ok, tt.id)
+		}
+	}
+	if _, ok := s.DefinitionForTerm("assessor"); ok {
+		t.Error("Expected unknown term lookup to fail")
+	}
+}
+
+func TestFromResults(t *testing.T) {
+	fetched := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	s := FromResults([]api.DocumentResult{
+		{
+			Document:     model.Document{Code: "VDR"},
+			FetchResult:  api.FetchResult{Code: "VDR", Origin: api.OriginStale, FetchedAt: fetched},
+			Requirements: []model.Requirement{{ID: "VDR-1", DocumentCode: "VDR"}, {ID: "VDR-2", DocumentCode: "VDR"}},
+		},
+		{
+			Document:    model.Document{Code: "FRD"},
+			FetchResult: api.FetchResult{Code: "FRD", Origin: api.OriginSource},
+			Definitions: []model.Definition{{ID: "FRD-1", Term: "Agency"}},
+		},
+	})
+
+	doc, ok := s.Document("VDR")
+	if !ok || doc.RequirementCount != 2 || !doc.Stale || !doc.FetchedAt.Equal(fetched) {
+		t.Errorf("Unexpected VDR document %+v", doc)
+	}
+	if doc, _ := s.Document("FRD"); doc.DefinitionCount != 1 || doc.Stale {
+		t.Errorf("Unexpected FRD document %+v", doc)
+	}
+	if len(s.Documents()) != 2 || s.Documents()[0].Code != "VDR" {
+		t.Error("Expected documents in result order")
+	}
+}
+
+func TestReferences(t *testing.T) {
+	s := testStore()
+
+	text := "Providers MUST notify each Federal\nAgency per VDR-CSO-DET and KSI-IAM-01,\nnot VDR-CSO-XYZ; see vulnerability detection."
+	var got []string
+	for _, ref := range s.References(text) {
+		got = append(got, fmt.Sprintf("%d:%s:%s", ref.Kind, ref.ID, text[ref.Start:ref.End]))
+	}
+	want := []string{
+		// The longest term wins, even across a line break
+		fmt.Sprintf("%d:FRD-ALL-01:Federal\nAgency", RefDefinition),
+		fmt.Sprintf("%d:VDR-CSO-DET:VDR-CSO-DET", RefRequirement),
+		fmt.Sprintf("%d:KSI-IAM-01:KSI-IAM-01", RefIndicator),
+		fmt.Sprintf("%d:FRD-ALL-02:vulnerability detection", RefDefinition),
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	// Terms only match whole words
+	if refs := s.References("Agencywide agencies"); len(refs) != 0 {
+		t.Errorf("Expected no references in partial words, got %+v", refs)
+	}
+}
+
+func TestUsages(t *testing.T) {
+	s := New(Contents{
+		Requirements: []model.Requirement{
+			{ID: "VDR-1", Name: "Agency notification", Statement: "Providers MUST notify the agency."},
+			{ID: "VDR-2", Statement: "Providers MUST report.", Following: []string{"to each Federal Agency"}},
+			{ID: "VDR-3", Statement: "Providers MUST fix each vulnerability.", Note: "Agencywide scans do not count."},
+		},
+		Definitions: []model.Definition{
+			{ID: "FRD-1", Term: "Agency", Alts: []string{"Federal Agency"}},
+			{ID: "FRD-2", Term: "Vulnerability"},
+			{ID: "FRD-3", Term: "Assessor"},
+		},
+		Indicators: []model.Indicator{
+			{ID: "KSI-1", Statement: "Track every VULNERABILITY.", Note: "Share with the agency."},
+		},
+	})
+
+	tests := []struct {
+		id   string
+		want []Usage
+	}{
+		// Each item is listed once, however often it uses the term
+		{"FRD-1", []Usage{{RefRequirement, "VDR-1"}, {RefRequirement, "VDR-2"}, {RefIndicator, "KSI-1"}}},
+		{"FRD-2", []Usage{{RefRequirement, "VDR-3"}, {RefIndicator, "KSI-1"}}},
+		{"FRD-3", nil},
+	}
+	for _, tt := range tests {
+		if got := s.Usages(tt.id); !slices.Equal(got, tt.want) {
+			t.Errorf("Usages(%s) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
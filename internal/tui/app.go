@@ -1,8 +1,11 @@
 package tui
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
@@ -10,7 +13,9 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/ethanolivertroy/fedramp-tui/internal/api"
+	"github.com/ethanolivertroy/fedramp-tui/internal/catalog"
 	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+	"github.com/ethanolivertroy/fedramp-tui/internal/store"
 )
 
 // ViewState represents the current view
@@ -21,20 +26,34 @@ const (
 	ViewRequirements
 	ViewDefinitions
 	ViewIndicators
+	ViewRFCs
+	ViewControls
 	ViewDetail
 )
 
 // AffectsOptions defines the cycle order for affects filtering
 var AffectsOptions = []string{"", "Providers", "Agencies", "Assessors", "FedRAMP"}
 
+// Stale documents are retried in the background, backing off between attempts
+const (
+	staleRetryInitial = 30 * time.Second
+	staleRetryMax     = 10 * time.Minute
+)
+
 // Messages
 type DataLoadedMsg struct {
-	Documents    []model.Document
-	Requirements []model.Requirement
-	Definitions  []model.Definition
-	Indicators   []model.Indicator
+	Results         []api.DocumentResult
+	SnapshotRelease string // Release of the embedded snapshot, if any document came from it
 }
 
+// DocumentsRefreshedMsg carries the results of a background refresh
+type DocumentsRefreshedMsg struct {
+	Results []api.DocumentResult
+}
+
+// staleRetryMsg triggers a background refresh of stale documents
+type staleRetryMsg struct{}
+
 type ErrorMsg struct {
 	Err error
 }
@@ -49,27 +68,55 @@ type Model struct {
 	width        int
 	height       int
 
-	// Data
-	documents    []model.Document
-	requirements []model.Requirement
-	definitions  []model.Definition
-	indicators   []model.Indicator
+	// Data, indexed for lookup
+	store *store.Store
+
+	// Per-document load results, keyed by document code, in display order
+	results         map[string]api.DocumentResult
+	order           []string
+	snapshotRelease string
+	staleRetryDelay time.Duration
+	retrying        bool // Retrying failed documents
 
 	// Filters
 	documentFilter string // Filter requirements by document code
 	keywordFilter  string // Filter requirements by keyword (MUST, SHOULD)
 	affectsFilter  string // Filter requirements by affected party (Providers, Agencies, Assessors, FedRAMP)
+	categoryFilter string // Filter requirements by category ID within the filtered document
+
+	groupByCategory bool // Show requirements under category headings
+	sortByUsage     bool // Sort definitions by how many items use them
+	allControls     bool // List every catalog control, not just those KSIs map to
+
+	// Spreadsheet exports
+	exportDir        string // Directory exports are written to
+	exportPerControl bool   // Export a row per indicator control mapping
 
 	// Selected item for detail view
 	selectedItem list.Item
 
+	// Cross-references in the detail view
+	links     *detailLinks  // Filled in as the detail view renders
+	linkIndex int           // Selected cross-reference, or -1
+	history   []detailState // Detail views to return to, most recent last
+
+	detailNotice string // Shown above the detail view's help, e.g. after an export
+
 	// Components
 	list          list.Model
 	spinner       spinner.Model
 	viewport      viewport.Model
 	viewportReady bool
 	apiClient     *api.Client
+	catalog       *catalog.Catalog // SP 800-53 controls, if a catalog is available
 	keys          KeyMap
+
+	// Clock used to decide which RFCs are open for comment
+	now func() time.Time
+
+	// Cancels in-flight fetches when the program quits
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 // ModelOption configures the TUI model
@@ -82,18 +129,47 @@ func WithRefresh(refresh bool) ModelOption {
 	}
 }
 
+// WithClient uses the given API client, e.g. one configured with a custom source
+func WithClient(client *api.Client) ModelOption {
+	return func(m *Model) {
+		m.apiClient = client
+	}
+}
+
+// WithCatalog shows SP 800-53 control details from an OSCAL catalog
+func WithCatalog(c *catalog.Catalog) ModelOption {
+	return func(m *Model) {
+		m.catalog = c
+	}
+}
+
+// WithExportDir writes exported spreadsheets to dir instead of the working
+// directory
+func WithExportDir(dir string) ModelOption {
+	return func(m *Model) {
+		m.exportDir = dir
+	}
+}
+
 // NewModel creates a new application model
 func NewModel(opts ...ModelOption) Model {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(PrimaryColor)
 
+	ctx, cancel := context.WithCancel(context.Background())
+
 	m := Model{
+		ctx:       ctx,
+		cancel:    cancel,
 		spinner:   s,
 		loading:   true,
 		view:      ViewHome,
 		apiClient: api.NewClient(),
 		keys:      DefaultKeyMap(),
+		store:     store.New(store.Contents{}),
+		linkIndex: -1,
+		now:       time.Now,
 	}
 
 	for _, opt := range opts {
@@ -113,67 +189,98 @@ func (m Model) Init() tea.Cmd {
 
 func (m Model) fetchData() tea.Cmd {
 	return func() tea.Msg {
-		docs, err := m.apiClient.FetchAllDocuments()
-		if err != nil {
-			return ErrorMsg{Err: err}
-		}
-
-		var allRequirements []model.Requirement
-		var definitions []model.Definition
-		var indicators []model.Indicator
-
-		// Parse each document
-		for code, data := range docs {
-			switch code {
-			case "FRD":
-				defs, err := m.apiClient.ParseDefinitions(data)
-				if err == nil {
-					definitions = defs
-				}
-			case "KSI":
-				inds, err := m.apiClient.ParseIndicators(data)
-				if err == nil {
-					indicators = inds
-				}
-			default:
-				reqs, err := m.apiClient.ParseRequirements(data, code)
-				if err == nil {
-					allRequirements = append(allRequirements, reqs...)
-				}
+		// A failed listing falls back to the last known document list
+		_ = m.apiClient.DiscoverContext(m.ctx)
+		results := m.apiClient.LoadContext(m.ctx, m.apiClient.DocumentCodes())
+
+		// Documents that failed are reported in the load status panel
+		msg := DataLoadedMsg{Results: results}
+		for _, res := range results {
+			if res.Origin == api.OriginSnapshot && res.Error == nil {
+				msg.SnapshotRelease = m.apiClient.SnapshotRelease()
+				break
 			}
 		}
+		return msg
+	}
+}
+
+// refreshDocuments reloads the given documents in the background
+func (m Model) refreshDocuments(codes []string) tea.Cmd {
+	return func() tea.Msg {
+		return DocumentsRefreshedMsg{Results: m.apiClient.LoadContext(m.ctx, codes)}
+	}
+}
 
-		// Build document list with counts and rich info
-		documents := api.GetDocumentMetadata()
-		reqCounts := make(map[string]int)
-		for _, r := range allRequirements {
-			reqCounts[r.DocumentCode]++
+// applyResults stores load results and rebuilds the flattened data from them.
+// A failed or stale refresh never replaces data that is already loaded.
+func (m *Model) applyResults(results []api.DocumentResult) {
+	if m.results == nil {
+		m.results = make(map[string]api.DocumentResult)
+	}
+	for _, res := range results {
+		if !slices.Contains(m.order, res.Code) {
+			m.order = append(m.order, res.Code)
 		}
-		for i := range documents {
-			documents[i].RequirementCount = reqCounts[documents[i].Code]
-			// Enrich with info from JSON
-			if data, ok := docs[documents[i].Code]; ok {
-				if info, err := m.apiClient.ParseDocumentInfo(data); err == nil {
-					api.EnrichDocument(&documents[i], info)
-				}
+		prev, ok := m.results[res.Code]
+		if ok && !prev.Failed() && (res.Failed() || res.Stale()) {
+			if res.Stale() {
+				prev.RefreshError = res.RefreshError
+				m.results[res.Code] = prev
 			}
+			continue
 		}
-		// Special counts for FRD and KSI
-		for i := range documents {
-			if documents[i].Code == "FRD" {
-				documents[i].RequirementCount = len(definitions)
-			} else if documents[i].Code == "KSI" {
-				documents[i].RequirementCount = len(indicators)
-			}
+		m.results[res.Code] = res
+	}
+	m.rebuildData()
+}
+
+// failedCodes returns the codes of documents that could not be fetched or parsed
+func (m Model) failedCodes() []string {
+	var codes []string
+	for _, code := range m.order {
+		if res, ok := m.results[code]; ok && res.Failed() {
+			codes = append(codes, code)
 		}
+	}
+	return codes
+}
 
-		return DataLoadedMsg{
-			Documents:    documents,
-			Requirements: allRequirements,
-			Definitions:  definitions,
-			Indicators:   indicators,
+// rebuildData indexes the per-document results, in document display order
+func (m *Model) rebuildData() {
+	results := make([]api.DocumentResult, 0, len(m.order))
+	for _, code := range m.order {
+		results = append(results, m.results[code])
+	}
+	m.store = store.FromResults(results)
+}
+
+// staleCodes returns the codes of documents served from expired cache entries
+func (m Model) staleCodes() []string {
+	var codes []string
+	for _, code := range m.order {
+		if res, ok := m.results[code]; ok && res.Stale() {
+			codes = append(codes, code)
 		}
 	}
+	return codes
+}
+
+// scheduleStaleRetry schedules the next background refresh while any
+// document is stale, doubling the delay each time
+func (m *Model) scheduleStaleRetry() tea.Cmd {
+	if len(m.staleCodes()) == 0 {
+		m.staleRetryDelay = 0
+		return nil
+	}
+	if m.staleRetryDelay == 0 {
+		m.staleRetryDelay = staleRetryInitial
+	} else {
+		m.staleRetryDelay = min(m.staleRetryDelay*2, staleRetryMax)
+	}
+	return tea.Tick(m.staleRetryDelay, func(time.Time) tea.Msg {
+		return staleRetryMsg{}
+	})
 }
 
 // Update handles messages and updates the model
@@ -182,15 +289,36 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		// Handle keys in detail view
 		if m.view == ViewDetail {
-			// ESC/Backspace/q to go back
+			// ESC/Backspace/q to go back, first through followed cross-references
 			if msg.Type == tea.KeyEsc || msg.Type == tea.KeyBackspace || msg.Type == tea.KeyEscape || msg.String() == "q" {
+				if m.goBack() {
+					return m, nil
+				}
 				m.view = m.previousView
 				m.viewportReady = false
 				m.updateListForView()
 				return m, nil
 			}
-			// Enter to navigate from document detail to its requirements
+			// Export the document being read: e to Markdown, E to HTML
+			if doc, ok := m.selectedItem.(model.DocumentItem); ok && (msg.String() == "e" || msg.String() == "E") {
+				m.detailNotice = m.exportDocument(doc.Code, msg.String() == "E")
+				return m, nil
+			}
+			// Tab through cross-references
+			switch msg.Type {
+			case tea.KeyTab:
+				m.cycleLink(1)
+				return m, nil
+			case tea.KeyShiftTab:
+				m.cycleLink(-1)
+				return m, nil
+			}
+			// Enter to follow the selected cross-reference, or to navigate
+			// from document detail to its requirements
 			if msg.Type == tea.KeyEnter {
+				if m.followLink() {
+					return m, nil
+				}
 				if doc, ok := m.selectedItem.(model.DocumentItem); ok {
 					switch doc.Code {
 					case "FRD":
@@ -202,6 +330,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					default:
 						m.view = ViewRequirements
 						m.documentFilter = doc.Code
+						m.categoryFilter = ""
 					}
 					m.viewportReady = false
 					m.updateListForView()
@@ -224,30 +353,37 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		switch msg.String() {
-		case "ctrl+c":
-			return m, tea.Quit
-		case "q":
-			return m, tea.Quit
+		case "ctrl+c", "q":
+			return m, m.quit()
 		case "enter":
 			// Handle Enter in list view to go to detail
 			if !m.loading && m.list.FilterState() != list.Filtering {
 				if item := m.list.SelectedItem(); item != nil {
-					m.selectedItem = item
-					m.previousView = m.view
-					m.view = ViewDetail
-					// Initialize viewport for scrolling
-					m.viewport = viewport.New(m.width-4, m.height-8)
-					m.viewport.SetContent(m.renderDetailContent())
-					m.viewportReady = true
+					m.showDetail(item)
 					return m, nil
 				}
 			}
+		case "d":
+			// Show schema diagnostics for all documents
+			if m.view == ViewHome && !m.loading {
+				m.showDetail(diagnosticsItem{})
+				return m, nil
+			}
+		case "r":
+			// Retry documents that failed to load
+			if m.view == ViewHome && !m.retrying {
+				if codes := m.failedCodes(); len(codes) > 0 {
+					m.retrying = true
+					return m, m.refreshDocuments(codes)
+				}
+			}
 		case "f":
 			// Clear all filters when in requirements view
-			if m.view == ViewRequirements && (m.documentFilter != "" || m.keywordFilter != "" || m.affectsFilter != "") {
+			if m.view == ViewRequirements && (m.documentFilter != "" || m.keywordFilter != "" || m.affectsFilter != "" || m.categoryFilter != "") {
 				m.documentFilter = ""
 				m.keywordFilter = ""
 				m.affectsFilter = ""
+				m.categoryFilter = ""
 				m.updateListForView()
 				return m, nil
 			}
@@ -266,6 +402,45 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.updateListForView()
 				return m, nil
 			}
+		case "c":
+			// Cycle through the categories of the filtered document
+			if m.view == ViewRequirements {
+				m.cycleCategoryFilter()
+				m.updateListForView()
+				return m, nil
+			}
+		case "C":
+			// Toggle grouping requirements by category
+			if m.view == ViewRequirements {
+				m.groupByCategory = !m.groupByCategory
+				m.updateListForView()
+				return m, nil
+			}
+		case "u":
+			// Toggle sorting definitions by usage
+			if m.view == ViewDefinitions {
+				m.sortByUsage = !m.sortByUsage
+				m.updateListForView()
+				return m, nil
+			}
+		case "e", "E":
+			// Export the visible list (e to CSV, E to XLSX) or, on the
+			// Documents view, the selected document (e to Markdown, E to HTML)
+			return m, m.exportView(msg.String() == "E")
+		case "p":
+			// Toggle exporting a row per control mapping
+			if m.view == ViewIndicators {
+				m.exportPerControl = !m.exportPerControl
+				m.updateListForView()
+				return m, nil
+			}
+		case "a":
+			// Toggle listing every catalog control
+			if m.view == ViewControls && m.catalog != nil {
+				m.allControls = !m.allControls
+				m.updateListForView()
+				return m, nil
+			}
 		case "m":
 			// Toggle MUST filter in requirements view
 			if m.view == ViewRequirements {
@@ -293,6 +468,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.view = ViewHome
 				m.documentFilter = ""
 				m.keywordFilter = ""
+				m.categoryFilter = ""
 				m.updateListForView()
 			}
 		case "2":
@@ -310,6 +486,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.view = ViewIndicators
 				m.updateListForView()
 			}
+		case "5":
+			if m.view != ViewDetail {
+				m.view = ViewRFCs
+				m.updateListForView()
+			}
+		case "6":
+			if m.view != ViewDetail {
+				m.view = ViewControls
+				m.updateListForView()
+			}
 		}
 
 	case tea.WindowSizeMsg:
@@ -333,15 +519,25 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case DataLoadedMsg:
 		m.loading = false
-		m.documents = msg.Documents
-		m.requirements = msg.Requirements
-		m.definitions = msg.Definitions
-		m.indicators = msg.Indicators
+		m.snapshotRelease = msg.SnapshotRelease
+		m.applyResults(msg.Results)
 
 		// Initialize list with documents
 		m.initList()
+		return m, m.scheduleStaleRetry()
+
+	case staleRetryMsg:
+		if codes := m.staleCodes(); len(codes) > 0 {
+			return m, m.refreshDocuments(codes)
+		}
 		return m, nil
 
+	case DocumentsRefreshedMsg:
+		m.retrying = false
+		m.applyResults(msg.Results)
+		m.refreshListItems()
+		return m, m.scheduleStaleRetry()
+
 	case ErrorMsg:
 		m.loading = false
 		m.err = msg.Err
@@ -358,8 +554,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// showDetail opens the scrollable detail view for an item
+func (m *Model) showDetail(item list.Item) {
+	m.previousView = m.view
+	m.view = ViewDetail
+	m.history = nil
+	m.openDetail(item)
+}
+
+// openDetail shows an item in the detail view, scrolled to the top
+func (m *Model) openDetail(item list.Item) {
+	m.selectedItem = item
+	m.linkIndex = -1
+	m.detailNotice = ""
+	// Initialize viewport for scrolling
+	m.viewport = viewport.New(m.width-4, m.height-8)
+	m.renderDetail()
+	m.viewportReady = true
+}
+
+// quit cancels any in-flight fetches and exits the program
+func (m Model) quit() tea.Cmd {
+	m.cancel()
+	return tea.Quit
+}
+
 func (m *Model) initList() {
 	delegate := NewItemDelegate()
+	delegate.Now = m.now
 	m.list = list.New(m.getDocumentItems(), delegate, m.width-4, m.height-10)
 	m.list.Title = "FedRAMP Documentation"
 	m.list.SetShowStatusBar(true)
@@ -382,15 +604,13 @@ func (m *Model) initList() {
 }
 
 func (m *Model) updateListForView() {
-	var items []list.Item
+	items := m.itemsForView()
 	var title string
 
 	switch m.view {
 	case ViewHome:
-		items = m.getDocumentItems()
 		title = "FedRAMP Documents"
 	case ViewRequirements:
-		items = m.getRequirementItems()
 		var filterHints []string
 		if m.documentFilter != "" {
 			filterHints = append(filterHints, m.documentFilter)
@@ -401,17 +621,44 @@ func (m *Model) updateListForView() {
 		if m.affectsFilter != "" {
 			filterHints = append(filterHints, m.affectsFilter)
 		}
+		if m.categoryFilter != "" {
+			filterHints = append(filterHints, m.categoryLabel(m.documentFilter, m.categoryFilter))
+		}
+		if m.groupByCategory {
+			filterHints = append(filterHints, "by category")
+		}
+		count := countRequirements(items)
 		if len(filterHints) > 0 {
-			title = fmt.Sprintf("Requirements (%d) [%s] - x: affects, m/s: keyword, f: clear", len(items), strings.Join(filterHints, ", "))
+			title = fmt.Sprintf("Requirements (%d) [%s] - x: affects, m/s: keyword, c/C: category, f: clear", count, strings.Join(filterHints, ", "))
 		} else {
-			title = fmt.Sprintf("FedRAMP Requirements (%d) - x: affects, m: MUST, s: SHOULD", len(items))
+			title = fmt.Sprintf("FedRAMP Requirements (%d) - x: affects, m: MUST, s: SHOULD, c/C: category", count)
 		}
 	case ViewDefinitions:
-		items = m.getDefinitionItems()
-		title = fmt.Sprintf("FedRAMP Definitions (%d)", len(m.definitions))
+		if m.sortByUsage {
+			title = fmt.Sprintf("FedRAMP Definitions (%d) [by usage] - u: document order", len(m.store.Definitions()))
+		} else {
+			title = fmt.Sprintf("FedRAMP Definitions (%d) - u: sort by usage", len(m.store.Definitions()))
+		}
 	case ViewIndicators:
-		items = m.getIndicatorItems()
-		title = fmt.Sprintf("Key Security Indicators (%d)", len(m.indicators))
+		title = fmt.Sprintf("Key Security Indicators (%d) - e/E: export CSV/XLSX, p: one row per control", len(m.store.Indicators()))
+		if m.exportPerControl {
+			title = fmt.Sprintf("Key Security Indicators (%d) [export per control] - e/E: export CSV/XLSX, p: one row per indicator", len(m.store.Indicators()))
+		}
+	case ViewRFCs:
+		title = fmt.Sprintf("Requests for Comment (%d) - %d open for comment", len(m.store.RFCs()), m.openRFCCount())
+	case ViewControls:
+		mapped := 0
+		for _, f := range m.store.ControlFamilies() {
+			mapped += len(f.Controls)
+		}
+		switch {
+		case m.catalog == nil:
+			title = fmt.Sprintf("SP 800-53 Controls (%d mapped by KSIs in %d families) - --catalog for control text", mapped, len(m.store.ControlFamilies()))
+		case m.allControls:
+			title = fmt.Sprintf("%s %s (%d controls, %d mapped by KSIs) - a: mapped only", m.catalog.Title, m.catalog.Version, len(items), mapped)
+		default:
+			title = fmt.Sprintf("SP 800-53 Controls (%d mapped by KSIs in %d families) - a: all controls", mapped, len(m.store.ControlFamilies()))
+		}
 	}
 
 	m.list.SetItems(items)
@@ -420,59 +667,235 @@ func (m *Model) updateListForView() {
 	m.list.ResetFilter()
 }
 
+// itemsForView returns the list items for the current view
+func (m Model) itemsForView() []list.Item {
+	switch m.view {
+	case ViewHome:
+		return m.getDocumentItems()
+	case ViewRequirements:
+		return m.getRequirementItems()
+	case ViewDefinitions:
+		return m.getDefinitionItems()
+	case ViewIndicators:
+		return m.getIndicatorItems()
+	case ViewRFCs:
+		return m.getRFCItems()
+	case ViewControls:
+		return m.getControlItems()
+	}
+	return nil
+}
+
+// refreshListItems reloads the current view's items after a background
+// refresh, keeping the selection and any active filter
+func (m *Model) refreshListItems() {
+	if m.view == ViewDetail {
+		return
+	}
+	m.list.SetItems(m.itemsForView())
+}
+
 func (m Model) getDocumentItems() []list.Item {
-	items := make([]list.Item, len(m.documents))
-	for i, d := range m.documents {
+	documents := m.store.Documents()
+	items := make([]list.Item, len(documents))
+	for i, d := range documents {
 		items[i] = model.DocumentItem{Document: d}
 	}
 	return items
 }
 
 func (m Model) getRequirementItems() []list.Item {
-	var items []list.Item
-	for _, r := range m.requirements {
-		// Filter by document if set
-		if m.documentFilter != "" && r.DocumentCode != m.documentFilter {
-			continue
+	requirements := m.store.FilterRequirements(m.requirementFilter())
+
+	if m.groupByCategory {
+		return groupByCategory(requirements)
+	}
+	items := make([]list.Item, len(requirements))
+	for i, r := range requirements {
+		items[i] = model.RequirementItem{Requirement: r}
+	}
+	return items
+}
+
+// requirementFilter returns the active requirement filters
+func (m Model) requirementFilter() store.Filter {
+	return store.Filter{
+		Document: m.documentFilter,
+		Category: m.categoryFilter,
+		Keyword:  m.keywordFilter,
+		Affects:  m.affectsFilter,
+	}
+}
+
+// groupByCategory arranges requirements under a heading for each category,
+// in the order categories first appear
+func groupByCategory(requirements []model.Requirement) []list.Item {
+	type group struct {
+		header model.CategoryItem
+		items  []list.Item
+	}
+	var groups []*group
+	index := make(map[[2]string]*group)
+	for _, r := range requirements {
+		key := [2]string{r.DocumentCode, r.Category.ID}
+		g, ok := index[key]
+		if !ok {
+			g = &group{header: model.CategoryItem{Category: r.Category, DocumentCode: r.DocumentCode}}
+			index[key] = g
+			groups = append(groups, g)
 		}
-		// Filter by keyword if set
-		if m.keywordFilter != "" && r.PrimaryKeyWord != m.keywordFilter {
-			continue
+		g.header.Count++
+		g.items = append(g.items, model.RequirementItem{Requirement: r})
+	}
+
+	items := make([]list.Item, 0, len(requirements)+len(groups))
+	for _, g := range groups {
+		items = append(items, g.header)
+		items = append(items, g.items...)
+	}
+	return items
+}
+
+// countRequirements counts the requirement items in a list, skipping headings
+func countRequirements(items []list.Item) int {
+	n := 0
+	for _, item := range items {
+		if _, ok := item.(model.RequirementItem); ok {
+			n++
 		}
-		// Filter by affects if set
-		if m.affectsFilter != "" {
-			found := false
-			for _, a := range r.Affects {
-				if a == m.affectsFilter {
-					found = true
-					break
-				}
-			}
-			if !found {
-				continue
-			}
+	}
+	return n
+}
+
+// categoryLabel returns the display name of a category within a document
+func (m Model) categoryLabel(code, id string) string {
+	for _, c := range m.store.Categories(code) {
+		if c.ID == id {
+			return c.Label()
 		}
-		items = append(items, model.RequirementItem{Requirement: r})
 	}
-	return items
+	return id
+}
+
+// cycleCategoryFilter steps through the categories of the filtered document.
+// Without a document filter, it narrows to the selected requirement's
+// document and category.
+func (m *Model) cycleCategoryFilter() {
+	if m.documentFilter == "" {
+		switch item := m.list.SelectedItem().(type) {
+		case model.RequirementItem:
+			m.documentFilter, m.categoryFilter = item.DocumentCode, item.Category.ID
+		case model.CategoryItem:
+			m.documentFilter, m.categoryFilter = item.DocumentCode, item.ID
+		}
+		return
+	}
+
+	options := []string{""}
+	for _, c := range m.store.Categories(m.documentFilter) {
+		options = append(options, c.ID)
+	}
+	next := 0
+	if i := slices.Index(options, m.categoryFilter); i >= 0 {
+		next = (i + 1) % len(options)
+	}
+	m.categoryFilter = options[next]
 }
 
 func (m Model) getDefinitionItems() []list.Item {
-	items := make([]list.Item, len(m.definitions))
-	for i, d := range m.definitions {
-		items[i] = model.DefinitionItem{Definition: d}
+	definitions := m.store.Definitions()
+	items := make([]model.DefinitionItem, len(definitions))
+	for i, d := range definitions {
+		items[i] = model.DefinitionItem{Definition: d, Usages: len(m.store.Usages(d.ID))}
 	}
-	return items
+	// Most used first, keeping document order among equals
+	if m.sortByUsage {
+		slices.SortStableFunc(items, func(a, b model.DefinitionItem) int { return b.Usages - a.Usages })
+	}
+
+	listItems := make([]list.Item, len(items))
+	for i, item := range items {
+		listItems[i] = item
+	}
+	return listItems
 }
 
 func (m Model) getIndicatorItems() []list.Item {
-	items := make([]list.Item, len(m.indicators))
-	for i, ind := range m.indicators {
+	indicators := m.store.Indicators()
+	items := make([]list.Item, len(indicators))
+	for i, ind := range indicators {
 		items[i] = model.IndicatorItem{Indicator: ind}
 	}
 	return items
 }
 
+// getRFCItems lists RFCs open for comment first, closing soonest first, then
+// upcoming ones, then the rest newest first
+func (m Model) getRFCItems() []list.Item {
+	now := m.now()
+	rank := map[model.CommentStatus]int{model.CommentOpen: 0, model.CommentUpcoming: 1}
+	rfcs := slices.Clone(m.store.RFCs())
+	slices.SortStableFunc(rfcs, func(a, b model.RFC) int {
+		ra, ok := rank[a.Status(now)]
+		if !ok {
+			ra = 2
+		}
+		rb, ok := rank[b.Status(now)]
+		if !ok {
+			rb = 2
+		}
+		if ra != rb {
+			return ra - rb
+		}
+		switch ra {
+		case 0:
+			return strings.Compare(a.EndDate, b.EndDate)
+		case 1:
+			return strings.Compare(a.StartDate, b.StartDate)
+		}
+		return 0 // Already newest first
+	})
+
+	items := make([]list.Item, len(rfcs))
+	for i, rfc := range rfcs {
+		items[i] = model.RFCItem{RFC: rfc}
+	}
+	return items
+}
+
+// getControlItems lists the controls that KSIs map to under their family
+// headings or, when allControls is set, every catalog control
+func (m Model) getControlItems() []list.Item {
+	var items []list.Item
+	if m.allControls && m.catalog != nil {
+		for _, c := range m.catalog.Controls() {
+			mapped, _ := m.store.MappedControl(c.ID)
+			items = append(items, model.CatalogControlItem{CatalogControl: c, Coverage: len(mapped.Indicators)})
+		}
+		return items
+	}
+	for _, f := range m.store.ControlFamilies() {
+		items = append(items, m.familyItem(f))
+		for _, c := range f.Controls {
+			if item, ok := m.controlItem(c.ID); ok {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+// openRFCCount returns the number of RFCs open for comment today
+func (m Model) openRFCCount() int {
+	n := 0
+	for _, rfc := range m.store.RFCs() {
+		if rfc.Status(m.now()) == model.CommentOpen {
+			n++
+		}
+	}
+	return n
+}
+
 // View renders the model
 func (m Model) View() string {
 	if m.loading {
@@ -494,14 +917,18 @@ func (m Model) View() string {
 		return m.renderDetailView()
 	default:
 		// Constrain list height to leave room for header
-		headerHeight := 4
+		header := m.renderHeader()
+		if m.view == ViewHome {
+			header += m.renderLoadStatus()
+		}
+		headerHeight := lipgloss.Height(header) + 1
 		listHeight := m.height - headerHeight - 4
 		if listHeight < 10 {
 			listHeight = 10 // minimum height
 		}
 		listStyle := lipgloss.NewStyle().MaxHeight(listHeight)
 		return AppStyle.Render(lipgloss.JoinVertical(lipgloss.Left,
-			m.renderHeader(),
+			header,
 			listStyle.Render(m.list.View())))
 	}
 }
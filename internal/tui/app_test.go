@@ -1,14 +1,31 @@
 package tui
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethanolivertroy/fedramp-tui/internal/api"
+	"github.com/ethanolivertroy/fedramp-tui/internal/catalog"
 	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+	"github.com/ethanolivertroy/fedramp-tui/internal/schema"
+	"github.com/ethanolivertroy/fedramp-tui/internal/store"
 )
 
+// newTestModel returns a model whose client never touches the user's cache.
+// Options may replace the client.
+func newTestModel(opts ...ModelOption) Model {
+	return NewModel(append([]ModelOption{WithClient(api.NewClient(api.WithCache(nil)))}, opts...)...)
+}
+
 func TestNewModel(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 
 	if m.view != ViewHome {
 		t.Errorf("Expected initial view to be ViewHome, got %v", m.view)
@@ -24,23 +41,25 @@ func TestNewModel(t *testing.T) {
 }
 
 func TestViewStateTransitions(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.loading = false
 
 	// Simulate data loaded
-	m.documents = []model.Document{
-		{Code: "FRD", Name: "FedRAMP Definitions"},
-		{Code: "VDR", Name: "Vulnerability Detection"},
-	}
-	m.requirements = []model.Requirement{
-		{ID: "VDR-1", DocumentCode: "VDR", Name: "Test Req"},
-	}
-	m.definitions = []model.Definition{
-		{ID: "FRD-1", Term: "Test Term"},
-	}
-	m.indicators = []model.Indicator{
-		{ID: "KSI-1", Name: "Test Indicator"},
-	}
+	m.store = store.New(store.Contents{
+		Documents: []model.Document{
+			{Code: "FRD", Name: "FedRAMP Definitions"},
+			{Code: "VDR", Name: "Vulnerability Detection"},
+		},
+		Requirements: []model.Requirement{
+			{ID: "VDR-1", DocumentCode: "VDR", Name: "Test Req"},
+		},
+		Definitions: []model.Definition{
+			{ID: "FRD-1", Term: "Test Term"},
+		},
+		Indicators: []model.Indicator{
+			{ID: "KSI-1", Name: "Test Indicator"},
+		},
+	})
 	m.initList()
 
 	tests := []struct {
@@ -68,18 +87,18 @@ func TestViewStateTransitions(t *testing.T) {
 }
 
 func TestEnterKeyInDetailView(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.loading = false
 	m.width = 100
 	m.height = 40
-	m.definitions = []model.Definition{
-		{ID: "FRD-1", Term: "Test"},
-	}
-	m.requirements = []model.Requirement{
-		{ID: "VDR-1", DocumentCode: "VDR"},
-	}
-	m.indicators = []model.Indicator{}
-	m.documents = []model.Document{}
+	m.store = store.New(store.Contents{
+		Definitions: []model.Definition{
+			{ID: "FRD-1", Term: "Test"},
+		},
+		Requirements: []model.Requirement{
+			{ID: "VDR-1", DocumentCode: "VDR"},
+		},
+	})
 	m.initList()
 
 	// Test with DocumentItem (FRD)
@@ -116,13 +135,15 @@ func TestEnterKeyInDetailView(t *testing.T) {
 }
 
 func TestDocumentFilter(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.loading = false
-	m.requirements = []model.Requirement{
-		{ID: "VDR-1", DocumentCode: "VDR", Name: "VDR Req 1"},
-		{ID: "VDR-2", DocumentCode: "VDR", Name: "VDR Req 2"},
-		{ID: "UCM-1", DocumentCode: "UCM", Name: "UCM Req 1"},
-	}
+	m.store = store.New(store.Contents{
+		Requirements: []model.Requirement{
+			{ID: "VDR-1", DocumentCode: "VDR", Name: "VDR Req 1"},
+			{ID: "VDR-2", DocumentCode: "VDR", Name: "VDR Req 2"},
+			{ID: "UCM-1", DocumentCode: "UCM", Name: "UCM Req 1"},
+		},
+	})
 
 	// No filter - should return all
 	items := m.getRequirementItems()
@@ -147,18 +168,17 @@ func TestDocumentFilter(t *testing.T) {
 }
 
 func TestClearFilter(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.loading = false
 	m.width = 100
 	m.height = 40
 	m.view = ViewRequirements
 	m.documentFilter = "VDR"
-	m.requirements = []model.Requirement{
-		{ID: "VDR-1", DocumentCode: "VDR"},
-	}
-	m.definitions = []model.Definition{}
-	m.indicators = []model.Indicator{}
-	m.documents = []model.Document{}
+	m.store = store.New(store.Contents{
+		Requirements: []model.Requirement{
+			{ID: "VDR-1", DocumentCode: "VDR"},
+		},
+	})
 	m.initList()
 
 	// Press 'f' to clear filter
@@ -172,14 +192,16 @@ func TestClearFilter(t *testing.T) {
 }
 
 func TestKeywordFilter(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.loading = false
-	m.requirements = []model.Requirement{
-		{ID: "VDR-1", DocumentCode: "VDR", PrimaryKeyWord: "MUST", Name: "Must Req 1"},
-		{ID: "VDR-2", DocumentCode: "VDR", PrimaryKeyWord: "SHOULD", Name: "Should Req 1"},
-		{ID: "VDR-3", DocumentCode: "VDR", PrimaryKeyWord: "MUST", Name: "Must Req 2"},
-		{ID: "UCM-1", DocumentCode: "UCM", PrimaryKeyWord: "SHOULD", Name: "UCM Should"},
-	}
+	m.store = store.New(store.Contents{
+		Requirements: []model.Requirement{
+			{ID: "VDR-1", DocumentCode: "VDR", PrimaryKeyWord: "MUST", Name: "Must Req 1"},
+			{ID: "VDR-2", DocumentCode: "VDR", PrimaryKeyWord: "SHOULD", Name: "Should Req 1"},
+			{ID: "VDR-3", DocumentCode: "VDR", PrimaryKeyWord: "MUST", Name: "Must Req 2"},
+			{ID: "UCM-1", DocumentCode: "UCM", PrimaryKeyWord: "SHOULD", Name: "UCM Should"},
+		},
+	})
 
 	// No filter - should return all
 	items := m.getRequirementItems()
@@ -219,17 +241,16 @@ func TestKeywordFilter(t *testing.T) {
 }
 
 func TestKeywordFilterToggle(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.loading = false
 	m.width = 100
 	m.height = 40
 	m.view = ViewRequirements
-	m.requirements = []model.Requirement{
-		{ID: "VDR-1", DocumentCode: "VDR", PrimaryKeyWord: "MUST"},
-	}
-	m.definitions = []model.Definition{}
-	m.indicators = []model.Indicator{}
-	m.documents = []model.Document{}
+	m.store = store.New(store.Contents{
+		Requirements: []model.Requirement{
+			{ID: "VDR-1", DocumentCode: "VDR", PrimaryKeyWord: "MUST"},
+		},
+	})
 	m.initList()
 
 	// Press 'm' to enable MUST filter
@@ -260,16 +281,12 @@ func TestKeywordFilterToggle(t *testing.T) {
 }
 
 func TestBackNavigation(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.loading = false
 	m.width = 100
 	m.height = 40
 	m.view = ViewDetail
 	m.previousView = ViewRequirements
-	m.requirements = []model.Requirement{}
-	m.definitions = []model.Definition{}
-	m.indicators = []model.Indicator{}
-	m.documents = []model.Document{}
 	m.initList()
 
 	// Press ESC to go back
@@ -293,3 +310,749 @@ func TestBackNavigation(t *testing.T) {
 		t.Errorf("Expected view ViewDefinitions after Backspace, got %v", updated.view)
 	}
 }
+
+func TestStaleDocumentsRefreshInBackground(t *testing.T) {
+	m := newTestModel()
+	m.width = 100
+	m.height = 40
+
+	stale := api.DocumentResult{
+		Document:    model.Document{Code: "VDR"},
+		FetchResult: api.FetchResult{Code: "VDR", Origin: api.OriginStale, FetchedAt: time.Now().Add(-72 * time.Hour)},
+		Requirements: []model.Requirement{
+			{ID: "VDR-1", DocumentCode: "VDR"},
+		},
+	}
+	newM, cmd := m.Update(DataLoadedMsg{Results: []api.DocumentResult{stale}})
+	updated := newM.(Model)
+
+	if cmd == nil {
+		t.Fatal("Expected a background retry to be scheduled")
+	}
+	if codes := updated.staleCodes(); len(codes) != 1 || codes[0] != "VDR" {
+		t.Errorf("Expected VDR to be stale, got %v", codes)
+	}
+	if doc := findDocument(updated.store.Documents(), "VDR"); doc == nil || !doc.Stale {
+		t.Error("Expected VDR document to be marked stale")
+	}
+
+	// A failed retry keeps the stale data
+	failed := api.DocumentResult{
+		Document:    model.Document{Code: "VDR"},
+		FetchResult: api.FetchResult{Code: "VDR", Error: errors.New("offline")},
+	}
+	newM, _ = updated.Update(DocumentsRefreshedMsg{Results: []api.DocumentResult{failed}})
+	updated = newM.(Model)
+	if len(updated.store.Requirements()) != 1 {
+		t.Errorf("Expected stale requirements to be kept, got %d", len(updated.store.Requirements()))
+	}
+
+	// A successful retry replaces it and stops retrying
+	fresh := api.DocumentResult{
+		Document:    model.Document{Code: "VDR"},
+		FetchResult: api.FetchResult{Code: "VDR", Origin: api.OriginSource, FetchedAt: time.Now()},
+		Requirements: []model.Requirement{
+			{ID: "VDR-1", DocumentCode: "VDR"},
+			{ID: "VDR-2", DocumentCode: "VDR"},
+		},
+	}
+	newM, cmd = updated.Update(DocumentsRefreshedMsg{Results: []api.DocumentResult{fresh}})
+	updated = newM.(Model)
+	if len(updated.staleCodes()) != 0 {
+		t.Error("Expected no stale documents after a successful refresh")
+	}
+	if len(updated.store.Requirements()) != 2 {
+		t.Errorf("Expected refreshed requirements, got %d", len(updated.store.Requirements()))
+	}
+	if cmd != nil {
+		t.Error("Expected no further retries")
+	}
+}
+
+func findDocument(docs []model.Document, code string) *model.Document {
+	for i := range docs {
+		if docs[i].Code == code {
+			return &docs[i]
+		}
+	}
+	return nil
+}
+
+func TestPartialLoadAndRetry(t *testing.T) {
+	m := newTestModel()
+	m.width = 100
+	m.height = 40
+
+	results := []api.DocumentResult{
+		{
+			Document:     model.Document{Code: "VDR"},
+			FetchResult:  api.FetchResult{Code: "VDR", Origin: api.OriginSource},
+			Requirements: []model.Requirement{{ID: "VDR-1", DocumentCode: "VDR"}},
+		},
+		{Document: model.Document{Code: "UCM"}, FetchResult: api.FetchResult{Code: "UCM", Error: errors.New("HTTP 500")}},
+		{Document: model.Document{Code: "ADS"}, FetchResult: api.FetchResult{Code: "ADS", Origin: api.OriginCache}, ParseError: errors.New("FRR.ADS section not found")},
+	}
+	newM, _ := m.Update(DataLoadedMsg{Results: results})
+	updated := newM.(Model)
+
+	if updated.err != nil {
+		t.Fatalf("Expected partial load to succeed, got %v", updated.err)
+	}
+	if len(updated.store.Requirements()) != 1 {
+		t.Errorf("Expected 1 requirement from the loaded document, got %d", len(updated.store.Requirements()))
+	}
+	if codes := updated.failedCodes(); len(codes) != 2 || codes[0] != "UCM" || codes[1] != "ADS" {
+		t.Errorf("Expected UCM and ADS to have failed, got %v", codes)
+	}
+
+	panel := updated.renderLoadStatus()
+	for _, want := range []string{"fetch failed", "parse failed", "HTTP 500", "FRR.ADS section not found"} {
+		if !strings.Contains(panel, want) {
+			t.Errorf("Expected load status panel to contain %q", want)
+		}
+	}
+
+	// Press 'r' to retry only the failed documents
+	newM, cmd := updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	updated = newM.(Model)
+	if cmd == nil || !updated.retrying {
+		t.Fatal("Expected a retry to start")
+	}
+
+	retried := []api.DocumentResult{
+		{
+			Document:     model.Document{Code: "UCM"},
+			FetchResult:  api.FetchResult{Code: "UCM", Origin: api.OriginSource},
+			Requirements: []model.Requirement{{ID: "UCM-1", DocumentCode: "UCM"}},
+		},
+		{Document: model.Document{Code: "ADS"}, FetchResult: api.FetchResult{Code: "ADS", Origin: api.OriginCache}, ParseError: errors.New("still broken")},
+	}
+	newM, _ = updated.Update(DocumentsRefreshedMsg{Results: retried})
+	updated = newM.(Model)
+	if updated.retrying {
+		t.Error("Expected retry to finish")
+	}
+	if codes := updated.failedCodes(); len(codes) != 1 || codes[0] != "ADS" {
+		t.Errorf("Expected only ADS to still fail, got %v", codes)
+	}
+	if len(updated.store.Requirements()) != 2 {
+		t.Errorf("Expected 2 requirements after retry, got %d", len(updated.store.Requirements()))
+	}
+}
+
+func TestSchemaDiagnostics(t *testing.T) {
+	m := newTestModel()
+	m.width = 100
+	m.height = 40
+
+	results := []api.DocumentResult{
+		{
+			Document:    model.Document{Code: "VDR"},
+			FetchResult: api.FetchResult{Code: "VDR", Origin: api.OriginSource},
+			Validation: api.Validation{Schema: "bundled", Violations: []schema.Violation{
+				{Pointer: "/FRR/VDR/base/requirements/3/impact", Message: "expected object, got string"},
+			}},
+		},
+		{Document: model.Document{Code: "FRD"}, FetchResult: api.FetchResult{Code: "FRD", Origin: api.OriginSource}, Validation: api.Validation{Schema: "bundled"}},
+	}
+	newM, _ := m.Update(DataLoadedMsg{Results: results})
+	updated := newM.(Model)
+
+	if panel := updated.renderLoadStatus(); !strings.Contains(panel, "1 schema issue in 1 document") || !strings.Contains(panel, "d: diagnostics") {
+		t.Errorf("Expected load status to report schema issues, got %q", panel)
+	}
+
+	newM, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	updated = newM.(Model)
+	if updated.view != ViewDetail {
+		t.Fatalf("Expected diagnostics in the detail view, got %v", updated.view)
+	}
+	content := updated.renderDetailContent()
+	for _, want := range []string{"/FRR/VDR/base/requirements/3/impact", "expected object, got string", "valid"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected diagnostics to contain %q", want)
+		}
+	}
+
+	newM, _ = updated.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if newM.(Model).view != ViewHome {
+		t.Error("Expected Esc to return to the Documents view")
+	}
+}
+
+func TestQuitCancelsFetches(t *testing.T) {
+	m := newTestModel()
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if cmd == nil {
+		t.Fatal("Expected quit command")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("Expected tea.QuitMsg")
+	}
+	if m.ctx.Err() == nil {
+		t.Error("Expected quitting to cancel in-flight fetches")
+	}
+}
+
+func TestHeaderShowsPinnedRef(t *testing.T) {
+	m := newTestModel(WithClient(api.NewClient(api.WithRef("v1.2.0"), api.WithCache(nil))))
+
+	if header := m.renderHeader(); !strings.Contains(header, "FedRAMP/docs@v1.2.0") {
+		t.Errorf("Expected header to show the pinned ref, got %q", header)
+	}
+}
+
+func TestCategoryFilterAndGrouping(t *testing.T) {
+	m := newTestModel()
+	m.loading = false
+	m.width = 100
+	m.height = 40
+
+	base := model.Category{ID: "base", Name: "Base", Application: "All providers"}
+	tr := model.Category{ID: "tr", Name: "Transitional"}
+	m.store = store.New(store.Contents{
+		Requirements: []model.Requirement{
+			{ID: "VDR-1", DocumentCode: "VDR", Category: base},
+			{ID: "VDR-2", DocumentCode: "VDR", Category: tr},
+			{ID: "VDR-3", DocumentCode: "VDR", Category: base},
+			{ID: "UCM-1", DocumentCode: "UCM", Category: base},
+		},
+	})
+	m.initList()
+	m.view = ViewRequirements
+	m.updateListForView()
+
+	// Without a document filter, 'c' narrows to the selected requirement's document and category
+	newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	updated := newM.(Model)
+	if updated.documentFilter != "VDR" || updated.categoryFilter != "base" {
+		t.Fatalf("Expected VDR/base filter, got %q/%q", updated.documentFilter, updated.categoryFilter)
+	}
+	if items := updated.list.Items(); len(items) != 2 {
+		t.Errorf("Expected 2 base requirements in VDR, got %d", len(items))
+	}
+
+	// Further presses cycle through the document's categories and back to all
+	for _, want := range []string{"tr", ""} {
+		newM, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+		updated = newM.(Model)
+		if updated.categoryFilter != want {
+			t.Errorf("Expected category filter %q, got %q", want, updated.categoryFilter)
+		}
+	}
+
+	// 'C' groups requirements under category headings in order of appearance
+	newM, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")})
+	updated = newM.(Model)
+	var got []string
+	for _, item := range updated.list.Items() {
+		switch i := item.(type) {
+		case model.CategoryItem:
+			got = append(got, fmt.Sprintf("%s(%d)", i.Name, i.Count))
+		case model.RequirementItem:
+			got = append(got, i.ID)
+		}
+	}
+	want := []string{"Base(2)", "VDR-1", "VDR-3", "Transitional(1)", "VDR-2"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Expected grouped items %v, got %v", want, got)
+	}
+}
+
+func TestRequirementHierarchyDetail(t *testing.T) {
+	m := newTestModel()
+	m.width = 100
+	m.store = store.New(store.Contents{
+		Requirements: []model.Requirement{
+			{ID: "VDR-1", Name: "Inventory", Statement: "MUST include the following:", Children: []string{"VDR-1-a"}, Following: []string{"A plain item"}},
+			{ID: "VDR-1-a", Statement: "Every asset", PrimaryKeyWord: "MUST", ParentID: "VDR-1"},
+		},
+	})
+
+	parent := m.renderRequirementDetail(model.RequirementItem{Requirement: m.store.Requirements()[0]})
+	for _, want := range []string{"Includes (1):", "VDR-1-a", "Every asset", "A plain item"} {
+		if !strings.Contains(parent, want) {
+			t.Errorf("Expected parent detail to contain %q", want)
+		}
+	}
+
+	child := m.renderRequirementDetail(model.RequirementItem{Requirement: m.store.Requirements()[1]})
+	if !strings.Contains(child, "Part of:") || !strings.Contains(child, "Inventory") {
+		t.Errorf("Expected child detail to reference its parent, got %q", child)
+	}
+
+	// The hierarchy can be followed with the link keys
+	m.loading = false
+	m.height = 40
+	m.showDetail(model.RequirementItem{Requirement: m.store.Requirements()[0]})
+	if len(m.links.refs) != 1 || m.links.refs[0].ID != "VDR-1-a" {
+		t.Fatalf("Expected a link to the child requirement, got %v", m.links.refs)
+	}
+	newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	newM, _ = newM.(Model).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newM.(Model)
+	if itemID(m.selectedItem) != "VDR-1-a" {
+		t.Fatalf("Expected to follow the link to VDR-1-a, got %v", m.selectedItem)
+	}
+	if len(m.links.refs) != 1 || m.links.refs[0].ID != "VDR-1" {
+		t.Errorf("Expected a link back to the parent requirement, got %v", m.links.refs)
+	}
+}
+
+func TestKSIRequirementsAndCounts(t *testing.T) {
+	m := newTestModel()
+	m.width = 100
+	m.height = 40
+
+	results := []api.DocumentResult{{
+		Document:     model.Document{Code: "KSI", Description: "Security indicators"},
+		FetchResult:  api.FetchResult{Code: "KSI", Origin: api.OriginSource},
+		Requirements: []model.Requirement{{ID: "FRR-KSI-01", DocumentCode: "KSI", PrimaryKeyWord: "MUST"}},
+		Indicators:   []model.Indicator{{ID: "KSI-CED-01"}, {ID: "KSI-CED-02"}},
+	}}
+	newM, _ := m.Update(DataLoadedMsg{Results: results})
+	updated := newM.(Model)
+
+	doc := model.DocumentItem{Document: updated.store.Documents()[0]}
+	if got := doc.Description(); got != "Security indicators (1 requirements, 2 indicators)" {
+		t.Errorf("Unexpected document description %q", got)
+	}
+	detail := updated.renderDocumentDetail(doc)
+	if !strings.Contains(detail, "Requirements:") || !strings.Contains(detail, "Indicators:") {
+		t.Error("Expected document detail to show both counts")
+	}
+
+	// KSI requirements are filtered alongside the other documents
+	newM, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	newM, _ = newM.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	if items := newM.(Model).list.Items(); len(items) != 1 {
+		t.Errorf("Expected the KSI MUST requirement, got %d items", len(items))
+	}
+}
+
+func TestRFCView(t *testing.T) {
+	m := newTestModel()
+	m.width = 100
+	m.height = 40
+	m.now = func() time.Time { return time.Date(2025, 8, 31, 15, 0, 0, 0, time.UTC) }
+
+	rfc := func(id, start, end string) model.RFC {
+		return model.RFC{ID: id, FullName: id + " standard", StartDate: start, EndDate: end}
+	}
+	results := []api.DocumentResult{
+		{
+			Document: model.Document{Code: "VDR", Releases: []model.Release{
+				{ID: "25.09A", RelatedRFCs: []model.RFC{rfc("RFC-1", "2025-01-01", "2025-02-01"), rfc("RFC-2", "2025-08-01", "2025-08-31")}},
+			}},
+			FetchResult: api.FetchResult{Code: "VDR", Origin: api.OriginSource},
+		},
+		{
+			Document: model.Document{Code: "SCN", Releases: []model.Release{
+				{ID: "25.10A", RelatedRFCs: []model.RFC{rfc("RFC-2", "2025-08-01", "2025-08-31"), rfc("RFC-3", "2025-09-15", "2025-10-15")}},
+			}},
+			FetchResult: api.FetchResult{Code: "SCN", Origin: api.OriginSource},
+		},
+	}
+	newM, _ := m.Update(DataLoadedMsg{Results: results})
+	newM, _ = newM.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("5")})
+	updated := newM.(Model)
+
+	if updated.view != ViewRFCs {
+		t.Fatalf("Expected RFC view, got %v", updated.view)
+	}
+	if !strings.Contains(updated.renderHeader(), "RFCs (1 open)") {
+		t.Error("Expected header to flag the open RFC")
+	}
+
+	// Open RFCs first (on their last day), then upcoming, then closed
+	var got []string
+	for _, item := range updated.list.Items() {
+		r := item.(model.RFCItem)
+		got = append(got, fmt.Sprintf("%s:%s", r.ID, r.Status(updated.now())))
+	}
+	want := []string{"RFC-2:open", "RFC-3:upcoming", "RFC-1:closed"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// Shared RFCs list every document that references them
+	detail := updated.renderRFCDetail(updated.list.Items()[0].(model.RFCItem))
+	for _, want := range []string{"OPEN 1d left", "VDR", "SCN", "25.09A, 25.10A", "2025-08-01 to 2025-08-31"} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("Expected RFC detail to contain %q", want)
+		}
+	}
+}
+
+func TestEffectiveWarnings(t *testing.T) {
+	m := newTestModel()
+	m.width = 120
+	m.height = 40
+
+	results := []api.DocumentResult{
+		{
+			Document: model.Document{
+				Code: "ADS",
+				Name: "Authorization Data Sharing",
+				EffectiveInfo: []model.EffectiveStatus{
+					{Version: "20x", Is: "required", Warnings: []string{"Phase One pilot has ended"}},
+					{Version: "rev5", Is: "optional"},
+				},
+				Authority: []model.Authority{
+					{Reference: "OMB M-24-15", Delegation: "FedRAMP PMO", DelegationURL: "https://example.gov/delegation"},
+				},
+			},
+			FetchResult: api.FetchResult{Code: "ADS", Origin: api.OriginSource},
+		},
+		{
+			Document:    model.Document{Code: "VDR", Name: "Vulnerability Detection and Response"},
+			FetchResult: api.FetchResult{Code: "VDR", Origin: api.OriginSource},
+		},
+	}
+	newM, _ := m.Update(DataLoadedMsg{Results: results})
+	updated := newM.(Model)
+
+	if header := updated.renderHeader(); !strings.Contains(header, "program status warnings for ADS (20x)") {
+		t.Errorf("Expected header to list documents with warnings, got %q", header)
+	}
+
+	updated.showDetail(updated.list.Items()[0])
+	detail := updated.renderDetailContent()
+	for _, want := range []string{"WARNINGS", "! 20x: Phase One pilot has ended", "Warning: Phase One pilot has ended", "Delegation: FedRAMP PMO", "https://example.gov/delegation"} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("Expected document detail to contain %q", want)
+		}
+	}
+
+	// Documents without warnings are not listed
+	if strings.Contains(updated.renderWarningBanner(), "VDR") {
+		t.Error("Expected no warning for a document without warnings")
+	}
+}
+
+func TestWrapIndented(t *testing.T) {
+	got := wrapIndented("  ! ", "Phase One pilot has ended for every provider", 20)
+	want := "  ! Phase One pilot has\n    ended for every\n    provider"
+	if got != want {
+		t.Errorf("Expected a hanging indent:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestCrossReferenceNavigation(t *testing.T) {
+	m := newTestModel()
+	m.loading = false
+	m.width = 100
+	m.height = 40
+	m.store = store.New(store.Contents{
+		Requirements: []model.Requirement{
+			{ID: "VDR-CSO-DET", DocumentCode: "VDR", Name: "Detection", Statement: "Providers MUST scan as required by VDR-CSO-RES.", Note: "See KSI-SVC-01."},
+			{ID: "VDR-CSO-RES", DocumentCode: "VDR", Name: "Response", Statement: "Providers MUST respond to each Vulnerability."},
+		},
+		Definitions: []model.Definition{
+			{ID: "FRD-ALL-01", Term: "Vulnerability", Text: "A weakness."},
+		},
+		Indicators: []model.Indicator{
+			{ID: "KSI-SVC-01", Name: "Secure Services"},
+		},
+	})
+	m.initList()
+	m.view = ViewRequirements
+	m.updateListForView()
+
+	key := func(m Model, msg tea.KeyMsg) Model {
+		newM, _ := m.Update(msg)
+		return newM.(Model)
+	}
+	tab := tea.KeyMsg{Type: tea.KeyTab}
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	esc := tea.KeyMsg{Type: tea.KeyEsc}
+
+	updated := key(m, enter)
+	if updated.view != ViewDetail || itemID(updated.selectedItem) != "VDR-CSO-DET" {
+		t.Fatalf("Expected detail of VDR-CSO-DET, got %v", updated.selectedItem)
+	}
+	var refs []string
+	for _, ref := range updated.links.refs {
+		refs = append(refs, ref.ID)
+	}
+	if want := []string{"VDR-CSO-RES", "KSI-SVC-01"}; !slices.Equal(refs, want) {
+		t.Fatalf("Expected references %v, got %v", want, refs)
+	}
+
+	// Tab wraps around the references
+	updated = key(key(key(updated, tab), tab), tab)
+	if updated.linkIndex != 0 {
+		t.Errorf("Expected tab to wrap to the first reference, got %d", updated.linkIndex)
+	}
+
+	// Follow the requirement, then the defined term in its statement
+	updated = key(updated, enter)
+	if itemID(updated.selectedItem) != "VDR-CSO-RES" {
+		t.Fatalf("Expected to jump to VDR-CSO-RES, got %v", updated.selectedItem)
+	}
+	updated = key(key(updated, tab), enter)
+	if itemID(updated.selectedItem) != "FRD-ALL-01" {
+		t.Fatalf("Expected to jump to the definition, got %v", updated.selectedItem)
+	}
+
+	// Esc walks back through the jumps, then leaves the detail view
+	updated = key(updated, esc)
+	if itemID(updated.selectedItem) != "VDR-CSO-RES" || updated.view != ViewDetail {
+		t.Errorf("Expected to return to VDR-CSO-RES, got %v", updated.selectedItem)
+	}
+	updated = key(updated, esc)
+	if itemID(updated.selectedItem) != "VDR-CSO-DET" || updated.linkIndex != 0 {
+		t.Errorf("Expected to return to VDR-CSO-DET with its reference selected, got %v (%d)", updated.selectedItem, updated.linkIndex)
+	}
+	updated = key(updated, esc)
+	if updated.view != ViewRequirements {
+		t.Errorf("Expected to return to the requirements list, got %v", updated.view)
+	}
+}
+
+func TestDefinitionUsages(t *testing.T) {
+	m := newTestModel()
+	m.loading = false
+	m.width = 100
+	m.height = 40
+	m.store = store.New(store.Contents{
+		Requirements: []model.Requirement{
+			{ID: "VDR-CSO-DET", Name: "Detection", Statement: "Providers MUST detect each vulnerability."},
+		},
+		Definitions: []model.Definition{
+			{ID: "FRD-ALL-01", Term: "Agency", Text: "A federal agency."},
+			{ID: "FRD-ALL-02", Term: "Vulnerability", Text: "A weakness."},
+		},
+		Indicators: []model.Indicator{
+			{ID: "KSI-SVC-01", Name: "Patching", Statement: "Remediate every Vulnerability."},
+		},
+	})
+	m.initList()
+	m.view = ViewDefinitions
+	m.updateListForView()
+
+	terms := func(m Model) []string {
+		var got []string
+		for _, item := range m.list.Items() {
+			d := item.(model.DefinitionItem)
+			got = append(got, fmt.Sprintf("%s:%d", d.Term, d.Usages))
+		}
+		return got
+	}
+	if want := []string{"Agency:0", "Vulnerability:2"}; !slices.Equal(terms(m), want) {
+		t.Errorf("Expected %v, got %v", want, terms(m))
+	}
+
+	newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	updated := newM.(Model)
+	if want := []string{"Vulnerability:2", "Agency:0"}; !slices.Equal(terms(updated), want) {
+		t.Errorf("Expected definitions sorted by usage %v, got %v", want, terms(updated))
+	}
+
+	// The detail lists each user of the term as a link
+	newM, _ = updated.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	updated = newM.(Model)
+	detail := updated.renderDetailContent()
+	for _, want := range []string{"Used in (2):", "VDR-CSO-DET Detection", "KSI-SVC-01 Patching"} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("Expected definition detail to contain %q", want)
+		}
+	}
+	newM, _ = updated.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	newM, _ = newM.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if id := itemID(newM.(Model).selectedItem); id != "KSI-SVC-01" {
+		t.Errorf("Expected to jump to KSI-SVC-01, got %s", id)
+	}
+}
+
+func TestControlCatalog(t *testing.T) {
+	cat, err := catalog.Parse([]byte(`{"catalog": {"metadata": {"title": "SP 800-53", "version": "5.1.1"}, "groups": [
+		{"id": "ac", "title": "Access Control", "controls": [
+			{"id": "ac-2", "title": "Account Management",
+			 "params": [{"id": "ac-02_odp.01", "label": "time period"}],
+			 "props": [{"name": "label", "value": "AC-2"}],
+			 "parts": [{"name": "statement", "parts": [{"name": "item", "props": [{"name": "label", "value": "a."}], "prose": "Review accounts every {{ insert: param, ac-02_odp.01 }}."}]}],
+			 "controls": [{"id": "ac-2.1", "title": "Automated System Account Management", "props": [{"name": "label", "value": "AC-2(1)"}]}]}
+		]}
+	]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := newTestModel(WithCatalog(cat))
+	m.loading = false
+	m.width = 100
+	m.height = 40
+	m.store = store.New(store.Contents{
+		Indicators: []model.Indicator{
+			{ID: "KSI-IAM-01", Name: "Account Management", Controls: []model.Control{{ControlID: "ac-2", Title: "Account Management"}, {ControlID: "ia-2", Title: "Identification"}}},
+		},
+	})
+	m.initList()
+
+	newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("6")})
+	updated := newM.(Model)
+	if updated.view != ViewControls || len(updated.list.Items()) != 4 {
+		t.Fatalf("Expected the controls view with 2 families of mapped controls, got %v with %d items", updated.view, len(updated.list.Items()))
+	}
+
+	// a lists every catalog control instead
+	newM, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	updated = newM.(Model)
+	if len(updated.list.Items()) != 2 {
+		t.Fatalf("Expected 2 catalog controls, got %d", len(updated.list.Items()))
+	}
+	if c := updated.list.Items()[0].(model.CatalogControlItem); c.Coverage != 1 {
+		t.Errorf("Expected AC-2 to be covered by 1 KSI, got %d", c.Coverage)
+	}
+
+	// Every control in an indicator's detail links, whether or not the catalog has it
+	updated.showDetail(model.IndicatorItem{Indicator: updated.store.Indicators()[0]})
+	if n := len(updated.links.refs); n != 2 {
+		t.Fatalf("Expected 2 control links, got %d", n)
+	}
+	newM, _ = updated.Update(tea.KeyMsg{Type: tea.KeyTab})
+	newM, _ = newM.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	updated = newM.(Model)
+	if id := itemID(updated.selectedItem); id != "ac-2" {
+		t.Fatalf("Expected to open ac-2, got %q", id)
+	}
+
+	detail := updated.renderDetailContent()
+	for _, want := range []string{
+		"AC-2 Account Management",
+		"a. Review accounts every [Assignment: time period].",
+		"Parameters (1):",
+		"Enhancements (1):",
+		"AC-2(1) Automated System Account Management",
+		"Mapped KSIs (1):",
+		"KSI-IAM-01",
+	} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("Expected control detail to contain %q", want)
+		}
+	}
+}
+
+func TestControlCoverage(t *testing.T) {
+	m := newTestModel()
+	m.loading = false
+	m.width = 100
+	m.height = 40
+	m.store = store.New(store.Contents{
+		Indicators: []model.Indicator{
+			{ID: "KSI-IAM-01", Name: "Account Management", Controls: []model.Control{{ControlID: "ac-2.1", Title: "Automated Account Management"}, {ControlID: "ia-2", Title: "Identification"}}},
+			{ID: "KSI-IAM-02", Name: "Access Reviews", Controls: []model.Control{{ControlID: "AC-2(1)", Title: "Automated Account Management"}}},
+		},
+	})
+	m.initList()
+	m.view = ViewControls
+	m.updateListForView()
+
+	items := m.list.Items()
+	if len(items) != 4 {
+		t.Fatalf("Expected 2 families with 1 control each, got %d items", len(items))
+	}
+	family, ok := items[0].(model.ControlFamilyItem)
+	if !ok || family.ID != "AC" || family.Name != "Access Control" || family.Mapped != 1 || family.Indicators != 2 {
+		t.Fatalf("Expected the AC family covered by 2 KSIs, got %+v", items[0])
+	}
+
+	// ac-2.1 and AC-2(1) are the same control
+	control := items[1].(model.CatalogControlItem)
+	if control.Label != "AC-2(1)" || control.Coverage != 2 {
+		t.Fatalf("Expected AC-2(1) covered by 2 KSIs, got %s with %d", control.Label, control.Coverage)
+	}
+
+	// Opening a control lists the KSIs that map to it
+	m.list.Select(1)
+	newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	updated := newM.(Model)
+	detail := updated.renderDetailContent()
+	for _, want := range []string{"Mapped KSIs (2):", "KSI-IAM-01", "KSI-IAM-02", "--catalog"} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("Expected control detail to contain %q", want)
+		}
+	}
+
+	// Opening a family links its controls
+	updated.showDetail(family)
+	if n := len(updated.links.refs); n != 1 {
+		t.Errorf("Expected 1 control link in the family detail, got %d", n)
+	}
+}
+
+func TestExportView(t *testing.T) {
+	dir := t.TempDir()
+	m := newTestModel(WithExportDir(dir))
+	m.loading = false
+	m.width = 100
+	m.height = 40
+	m.now = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+	m.store = store.New(store.Contents{
+		Requirements: []model.Requirement{
+			{ID: "VDR-1", DocumentCode: "VDR", Name: "Detect vulnerabilities", PrimaryKeyWord: "MUST"},
+			{ID: "VDR-2", DocumentCode: "VDR", Name: "Remediate vulnerabilities", PrimaryKeyWord: "MUST"},
+			{ID: "VDR-3", DocumentCode: "VDR", Name: "Report", PrimaryKeyWord: "SHOULD"},
+		},
+	})
+	m.initList()
+	m.view = ViewRequirements
+	m.keywordFilter = "MUST"
+	m.updateListForView()
+	// The / filter narrows the export further
+	m.list.SetFilterText("Remediate")
+
+	newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	_ = newM.(Model)
+	data, err := os.ReadFile(filepath.Join(dir, "fedramp-requirements-20260301-120000.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[1], "VDR-2,VDR,") {
+		t.Errorf("Expected only VDR-2 to be exported, got:\n%s", data)
+	}
+}
+
+func TestExportDocument(t *testing.T) {
+	dir := t.TempDir()
+	m := newTestModel(WithExportDir(dir))
+	m.loading = false
+	m.width = 100
+	m.height = 40
+	m.now = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+	m.store = store.New(store.Contents{
+		Documents: []model.Document{{Code: "VDR", Name: "Vulnerability Detection and Response", Purpose: "Find and fix vulnerabilities."}},
+		Requirements: []model.Requirement{
+			{ID: "VDR-1", DocumentCode: "VDR", Name: "Detect vulnerabilities", PrimaryKeyWord: "MUST"},
+		},
+	})
+	m.initList()
+	m.view = ViewHome
+	m.updateListForView()
+
+	// e on the Documents view writes the selected document as Markdown
+	newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	m = newM.(Model)
+	data, err := os.ReadFile(filepath.Join(dir, "fedramp-vdr-20260301-120000.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "## Purpose") || !strings.Contains(string(data), "#### VDR-1: Detect vulnerabilities") {
+		t.Errorf("Expected the whole document, got:\n%s", data)
+	}
+
+	// E in the document's detail view writes HTML and says where
+	m.showDetail(m.list.SelectedItem())
+	newM, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("E")})
+	m = newM.(Model)
+	path := filepath.Join(dir, "fedramp-vdr-20260301-120000.html")
+	if _, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(m.View(), "Exported VDR to") {
+		t.Errorf("Expected an export notice in the detail view, got:\n%s", m.View())
+	}
+}
@@ -0,0 +1,190 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethanolivertroy/fedramp-tui/internal/catalog"
+	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+	"github.com/ethanolivertroy/fedramp-tui/internal/store"
+)
+
+// lookupControl finds a control in the catalog, if one is loaded
+func (m Model) lookupControl(id string) (model.CatalogControl, bool) {
+	if m.catalog == nil {
+		return model.CatalogControl{}, false
+	}
+	return m.catalog.Control(id)
+}
+
+// controlItem returns a control with its KSI coverage. Controls missing from
+// the catalog, or all of them when no catalog is loaded, are shown with the
+// title the KSIs give them.
+func (m Model) controlItem(id string) (model.CatalogControlItem, bool) {
+	mapped, isMapped := m.store.MappedControl(id)
+	c, ok := m.lookupControl(id)
+	if !ok {
+		if !isMapped {
+			return model.CatalogControlItem{}, false
+		}
+		family := catalog.Family(mapped.ID)
+		c = model.CatalogControl{
+			ID:          catalog.OSCALID(mapped.ID),
+			Label:       mapped.ID,
+			Title:       mapped.Title,
+			Family:      strings.ToLower(family),
+			FamilyTitle: catalog.Families[family],
+		}
+	}
+	return model.CatalogControlItem{CatalogControl: c, Coverage: len(mapped.Indicators)}, true
+}
+
+// familyItem returns the heading for a family of mapped controls
+func (m Model) familyItem(f store.ControlFamily) model.ControlFamilyItem {
+	item := model.ControlFamilyItem{ID: f.ID, Name: f.Name, Mapped: len(f.Controls), Indicators: f.Indicators}
+	if m.catalog != nil {
+		for _, c := range m.catalog.Controls() {
+			if catalog.Family(c.ID) == f.ID && !c.Withdrawn {
+				item.Total++
+			}
+		}
+	}
+	return item
+}
+
+// renderFamilyDetail lists the controls of a family that KSIs map to
+func (m Model) renderFamilyDetail(f model.ControlFamilyItem) string {
+	var b strings.Builder
+
+	b.WriteString(DetailTitleStyle.Render(f.Title()))
+	b.WriteString("\n")
+	b.WriteString(CategoryStyle.Render(f.Description()))
+	b.WriteString("\n\n")
+
+	var family store.ControlFamily
+	for _, cf := range m.store.ControlFamilies() {
+		if cf.ID == f.ID {
+			family = cf
+		}
+	}
+	b.WriteString(DetailLabelStyle.Render(fmt.Sprintf("Controls (%d):", len(family.Controls))))
+	b.WriteString("\n")
+	for _, c := range family.Controls {
+		b.WriteString("  ")
+		m.writeLink(&b, store.RefControl, c.ID, c.ID)
+		b.WriteString(DimStyle.Render(fmt.Sprintf(" %s (%d KSIs)", c.Title, len(c.Indicators))))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// renderControlDetail shows the full text of an SP 800-53 control, its
+// parameters and enhancements, and the KSIs that map to it
+func (m Model) renderControlDetail(c model.CatalogControlItem) string {
+	var b strings.Builder
+
+	b.WriteString(DetailTitleStyle.Render(c.Label + " " + c.CatalogControl.Title))
+	b.WriteString("\n")
+	b.WriteString(CategoryStyle.Render(c.FamilyTitle))
+	if c.Withdrawn {
+		b.WriteString(" ")
+		b.WriteString(RetiredStyle.Render("WITHDRAWN"))
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(DetailLabelStyle.Render("ID:"))
+	b.WriteString(DetailValueStyle.Render(c.ID))
+	b.WriteString("\n")
+
+	if c.Parent != "" {
+		b.WriteString(DetailLabelStyle.Render("Enhances:"))
+		parent, _ := m.lookupControl(c.Parent)
+		m.writeLink(&b, store.RefControl, c.Parent, parent.Label)
+		b.WriteString(DimStyle.Render(" " + parent.Title))
+		b.WriteString("\n")
+	}
+
+	// Statement, with parameters shown as placeholders
+	if len(c.Statement) > 0 {
+		b.WriteString("\n")
+		b.WriteString(DetailLabelStyle.Render("Statement:"))
+		b.WriteString("\n")
+		for _, part := range c.Statement {
+			indent := strings.Repeat("  ", part.Depth+1)
+			b.WriteString(indent)
+			if part.Label != "" {
+				b.WriteString(ControlStyle.Render(part.Label + " "))
+			}
+			text := wrapText(part.Prose, m.width-14-len(indent))
+			b.WriteString(DetailValueStyle.Render(strings.ReplaceAll(text, "\n", "\n"+indent+"   ")))
+			b.WriteString("\n")
+		}
+	}
+
+	if len(c.Params) > 0 {
+		b.WriteString("\n")
+		b.WriteString(DetailLabelStyle.Render(fmt.Sprintf("Parameters (%d):", len(c.Params))))
+		b.WriteString("\n")
+		for _, p := range c.Params {
+			b.WriteString(ControlStyle.Render("  " + p.ID + ": "))
+			b.WriteString(DetailValueStyle.Render(wrapText(p.Placeholder(), m.width-14)))
+			b.WriteString("\n")
+			for _, g := range p.Guidelines {
+				b.WriteString(DimStyle.Render("    " + wrapText(g, m.width-18)))
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	if c.Guidance != "" {
+		b.WriteString("\n")
+		b.WriteString(DetailLabelStyle.Render("Discussion:"))
+		b.WriteString("\n")
+		b.WriteString(DimStyle.Render(wrapText(c.Guidance, m.width-10)))
+		b.WriteString("\n")
+	}
+
+	if len(c.Enhancements) > 0 {
+		b.WriteString("\n")
+		b.WriteString(DetailLabelStyle.Render(fmt.Sprintf("Enhancements (%d):", len(c.Enhancements))))
+		b.WriteString("\n")
+		for _, id := range c.Enhancements {
+			enh, _ := m.lookupControl(id)
+			b.WriteString("  ")
+			m.writeLink(&b, store.RefControl, id, enh.Label)
+			b.WriteString(DimStyle.Render(" " + enh.Title))
+			if enh.Withdrawn {
+				b.WriteString(RetiredStyle.Render(" (withdrawn)"))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	// KSIs that map to this control
+	indicators := m.store.IndicatorsForControl(c.ID)
+	b.WriteString("\n")
+	b.WriteString(DetailLabelStyle.Render(fmt.Sprintf("Mapped KSIs (%d):", len(indicators))))
+	b.WriteString("\n")
+	for _, ind := range indicators {
+		b.WriteString("  ")
+		m.writeLink(&b, store.RefIndicator, ind.ID, ind.ID)
+		b.WriteString(DimStyle.Render(" " + ind.Name))
+		b.WriteString("\n")
+	}
+
+	_, inCatalog := m.lookupControl(c.ID)
+	switch {
+	case m.catalog == nil:
+		b.WriteString("\n")
+		b.WriteString(DimStyle.Render("Start with --catalog <OSCAL catalog JSON> for the control text"))
+	case !inCatalog:
+		b.WriteString("\n")
+		b.WriteString(DimStyle.Render(fmt.Sprintf("Not found in %s %s", m.catalog.Title, m.catalog.Version)))
+	default:
+		b.WriteString("\n")
+		b.WriteString(DimStyle.Render(fmt.Sprintf("Source: %s %s (%s)", m.catalog.Title, m.catalog.Version, m.catalog.Source)))
+	}
+
+	return b.String()
+}
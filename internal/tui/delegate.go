@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -14,10 +15,11 @@ import (
 // ItemDelegate handles rendering of list items
 type ItemDelegate struct {
 	ShowDescription bool
+	Now             func() time.Time // Clock for RFC comment status
 }
 
 func NewItemDelegate() ItemDelegate {
-	return ItemDelegate{ShowDescription: true}
+	return ItemDelegate{ShowDescription: true, Now: time.Now}
 }
 
 func (d ItemDelegate) Height() int {
@@ -44,12 +46,19 @@ func (d ItemDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 		title = i.Title()
 		desc = i.Description()
 		badges = append(badges, DocumentBadge(i.Code))
+		if i.Stale {
+			badges = append(badges, StaleBadge(formatAge(i.FetchedAt)))
+		}
 
 	case model.RequirementItem:
 		title = i.Name
 		if title == "" {
 			title = i.ID
 		}
+		// Mark sub-requirements of a parent requirement
+		if i.ParentID != "" {
+			title = "↳ " + title
+		}
 		// Build description line with keyword indicator and impact as colored text
 		var descParts []string
 		if i.PrimaryKeyWord != "" {
@@ -59,10 +68,21 @@ func (d ItemDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 		descParts = append(descParts, truncate(i.Statement, 60))
 		desc = strings.Join(descParts, " | ")
 		badges = append(badges, DocumentBadge(i.DocumentCode))
+		if label := i.Category.Label(); label != "" {
+			badges = append(badges, CategoryStyle.Render(label))
+		}
+
+	case model.CategoryItem:
+		title = CategoryHeaderStyle.Render(i.Title())
+		desc = truncate(i.Application, 80)
+		badges = append(badges, DocumentBadge(i.DocumentCode))
 
 	case model.DefinitionItem:
 		title = i.Term
 		desc = truncate(i.Text, 80)
+		if i.Usages > 0 {
+			badges = append(badges, DimStyle.Render(fmt.Sprintf("used in %d", i.Usages)))
+		}
 
 	case model.IndicatorItem:
 		title = i.Name
@@ -75,6 +95,30 @@ func (d ItemDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 			badges = append(badges, ControlStyle.Render(fmt.Sprintf("%d controls", len(i.Controls))))
 		}
 
+	case model.RFCItem:
+		title = i.RFC.Title()
+		desc = i.Description()
+		if badge := CommentStatusBadge(i.RFC, d.Now()); badge != "" {
+			badges = append(badges, badge)
+		}
+
+	case model.ControlFamilyItem:
+		title = CategoryHeaderStyle.Render(i.Title())
+		desc = i.Description()
+
+	case model.CatalogControlItem:
+		title = i.Label + " " + i.CatalogControl.Title
+		if i.IsEnhancement() {
+			title = "↳ " + title
+		}
+		desc = i.FamilyTitle
+		if i.Withdrawn {
+			badges = append(badges, RetiredStyle.Render("WITHDRAWN"))
+		}
+		if i.Coverage > 0 {
+			badges = append(badges, ControlStyle.Render(fmt.Sprintf("%d KSIs", i.Coverage)))
+		}
+
 	default:
 		title = item.FilterValue()
 	}
@@ -108,6 +152,14 @@ func (d ItemDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 	}
 }
 
+// plural formats a count with its noun, e.g. "1 issue" or "2 issues"
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
 func truncate(s string, max int) string {
 	s = strings.ReplaceAll(s, "\n", " ")
 	s = strings.TrimSpace(s)
@@ -0,0 +1,79 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diagnosticsItem selects the schema diagnostics page in the detail view
+type diagnosticsItem struct{}
+
+func (diagnosticsItem) FilterValue() string { return "" }
+
+// schemaIssueCounts returns the total number of schema violations and the
+// number of documents that have any
+func (m Model) schemaIssueCounts() (issues, documents int) {
+	for _, code := range m.order {
+		if n := len(m.results[code].Validation.Violations); n > 0 {
+			issues += n
+			documents++
+		}
+	}
+	return issues, documents
+}
+
+// renderDiagnostics lists the schema violations of every loaded document
+func (m Model) renderDiagnostics() string {
+	var b strings.Builder
+
+	b.WriteString(DetailTitleStyle.Render("Schema Diagnostics"))
+	b.WriteString("\n")
+	issues, documents := m.schemaIssueCounts()
+	if issues == 0 {
+		b.WriteString(LoadOKStyle.Render("All loaded documents match their schemas"))
+	} else {
+		b.WriteString(LoadWarnStyle.Render(fmt.Sprintf("%d issues in %d documents", issues, documents)))
+	}
+	b.WriteString("\n")
+
+	for _, code := range m.order {
+		res, ok := m.results[code]
+		if !ok {
+			continue
+		}
+		v := res.Validation
+
+		b.WriteString("\n")
+		b.WriteString(DocumentBadge(code))
+		b.WriteString(" ")
+		switch {
+		case res.Error != nil:
+			b.WriteString(DimStyle.Render("not validated: fetch failed"))
+			b.WriteString("\n")
+			continue
+		case v.Valid():
+			b.WriteString(LoadOKStyle.Render("valid"))
+		default:
+			b.WriteString(LoadWarnStyle.Render(fmt.Sprintf("%d issues", len(v.Violations))))
+		}
+		b.WriteString(DimStyle.Render(fmt.Sprintf("  schema: %s", v.Schema)))
+		b.WriteString("\n")
+
+		if v.SchemaError != nil {
+			b.WriteString(NoteStyle.Render(wrapText(fmt.Sprintf("Declared schema unavailable, used bundled schema: %v", v.SchemaError), m.width-14)))
+			b.WriteString("\n")
+		}
+		for _, violation := range v.Violations {
+			pointer := violation.Pointer
+			if pointer == "" {
+				pointer = "/"
+			}
+			b.WriteString(ControlStyle.Render("  " + pointer))
+			b.WriteString("\n")
+			b.WriteString(DetailValueStyle.Render("    " + wrapText(violation.Message, m.width-14)))
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethanolivertroy/fedramp-tui/internal/export"
+	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+)
+
+// exportView writes the items visible in the current list, after any filters
+// and the / filter, to a CSV or, with alt, an XLSX spreadsheet in the export
+// directory. On the Documents view it writes the selected document as
+// Markdown or, with alt, HTML. The outcome is shown in the list's status bar.
+func (m *Model) exportView(alt bool) tea.Cmd {
+	if m.view == ViewHome {
+		doc, ok := m.list.SelectedItem().(model.DocumentItem)
+		if !ok {
+			return m.list.NewStatusMessage("Nothing to export in this view")
+		}
+		return m.list.NewStatusMessage(m.exportDocument(doc.Code, alt))
+	}
+
+	table, ok := m.exportTable()
+	if !ok {
+		return m.list.NewStatusMessage("Nothing to export in this view")
+	}
+	format := export.CSV
+	if alt {
+		format = export.XLSX
+	}
+	path := m.exportPath(table.Name, format)
+	if err := export.WriteFile(path, func(w io.Writer) error { return export.Write(w, format, table) }); err != nil {
+		return m.list.NewStatusMessage(LoadFailedStyle.Render("Export failed: " + err.Error()))
+	}
+	return m.list.NewStatusMessage(fmt.Sprintf("Exported %d rows to %s", len(table.Rows), path))
+}
+
+// exportDocument writes a whole document as Markdown or, with alt, HTML to
+// the export directory and returns a message saying where
+func (m Model) exportDocument(code string, alt bool) string {
+	d, ok := export.NewDocument(m.store, code, m.apiClient.SourceLabel())
+	if !ok {
+		return LoadFailedStyle.Render(code + " is not loaded")
+	}
+	format := export.Markdown
+	if alt {
+		format = export.HTML
+	}
+	path := m.exportPath(code, format)
+	if err := export.WriteFile(path, func(w io.Writer) error { return export.WriteDocument(w, format, d) }); err != nil {
+		return LoadFailedStyle.Render("Export failed: " + err.Error())
+	}
+	return fmt.Sprintf("Exported %s to %s", code, path)
+}
+
+// exportPath names an export file after its contents and the current time
+func (m Model) exportPath(name, format string) string {
+	return filepath.Join(m.exportDir, fmt.Sprintf("fedramp-%s-%s.%s", strings.ToLower(name), m.now().Format("20060102-150405"), format))
+}
+
+// exportTable returns the visible requirements, definitions or indicators
+func (m Model) exportTable() (export.Table, bool) {
+	source := m.apiClient.SourceLabel()
+	items := m.list.VisibleItems()
+	switch m.view {
+	case ViewRequirements:
+		var requirements []model.Requirement
+		for _, item := range items {
+			// Category headings are skipped
+			if r, ok := item.(model.RequirementItem); ok {
+				requirements = append(requirements, r.Requirement)
+			}
+		}
+		return export.Requirements(requirements, source), true
+	case ViewDefinitions:
+		var definitions []model.Definition
+		for _, item := range items {
+			if d, ok := item.(model.DefinitionItem); ok {
+				definitions = append(definitions, d.Definition)
+			}
+		}
+		return export.Definitions(definitions, source), true
+	case ViewIndicators:
+		var indicators []model.Indicator
+		for _, item := range items {
+			if ind, ok := item.(model.IndicatorItem); ok {
+				indicators = append(indicators, ind.Indicator)
+			}
+		}
+		return export.Indicators(indicators, m.exportPerControl, source), true
+	}
+	return export.Table{}, false
+}
@@ -4,17 +4,19 @@ import "github.com/charmbracelet/bubbles/key"
 
 // KeyMap defines all key bindings
 type KeyMap struct {
-	Up       key.Binding
-	Down     key.Binding
-	Enter    key.Binding
-	Back     key.Binding
-	Quit     key.Binding
-	Help     key.Binding
-	Home     key.Binding
-	Reqs     key.Binding
-	Defs     key.Binding
+	Up         key.Binding
+	Down       key.Binding
+	Enter      key.Binding
+	Back       key.Binding
+	Quit       key.Binding
+	Help       key.Binding
+	Home       key.Binding
+	Reqs       key.Binding
+	Defs       key.Binding
 	Indicators key.Binding
-	Filter   key.Binding
+	RFCs       key.Binding
+	Controls   key.Binding
+	Filter     key.Binding
 }
 
 // DefaultKeyMap returns the default key bindings
@@ -60,6 +62,14 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("4"),
 			key.WithHelp("4", "indicators"),
 		),
+		RFCs: key.NewBinding(
+			key.WithKeys("5"),
+			key.WithHelp("5", "rfcs"),
+		),
+		Controls: key.NewBinding(
+			key.WithKeys("6"),
+			key.WithHelp("6", "controls"),
+		),
 		Filter: key.NewBinding(
 			key.WithKeys("/"),
 			key.WithHelp("/", "filter"),
@@ -76,7 +86,7 @@ func (k KeyMap) ShortHelp() []key.Binding {
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Enter, k.Back},
-		{k.Home, k.Reqs, k.Defs, k.Indicators},
+		{k.Home, k.Reqs, k.Defs, k.Indicators, k.RFCs, k.Controls},
 		{k.Filter, k.Help, k.Quit},
 	}
 }
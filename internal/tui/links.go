@@ -0,0 +1,182 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+	"github.com/ethanolivertroy/fedramp-tui/internal/store"
+)
+
+// detailLinks records the cross-references shown in the detail view, in
+// display order, as they are rendered
+type detailLinks struct {
+	refs  []store.Reference
+	lines []int // Line of the detail content each reference is on
+}
+
+// detailState is a detail view to return to after following a cross-reference
+type detailState struct {
+	item      list.Item
+	offset    int
+	linkIndex int
+}
+
+// itemID returns the ID of a detail item, so it does not link to itself
+func itemID(item list.Item) string {
+	switch item := item.(type) {
+	case model.RequirementItem:
+		return item.ID
+	case model.IndicatorItem:
+		return item.ID
+	case model.DefinitionItem:
+		return item.ID
+	case model.CatalogControlItem:
+		return item.ID
+	}
+	return ""
+}
+
+// writeLinked wraps text and writes it with its cross-references highlighted,
+// recording each one so it can be selected
+func (m Model) writeLinked(b *strings.Builder, text string, width int, style lipgloss.Style) {
+	wrapped := wrapText(text, width)
+	plain := style.UnsetMargins()
+	line := strings.Count(b.String(), "\n") + style.GetMarginTop()
+	self := itemID(m.selectedItem)
+
+	var out strings.Builder
+	pos := 0
+	for _, ref := range m.store.References(wrapped) {
+		if ref.ID == self {
+			continue
+		}
+		out.WriteString(renderLines(plain, wrapped[pos:ref.Start]))
+		linkStyle := m.addLink(ref, line+strings.Count(wrapped[:ref.Start], "\n"))
+		out.WriteString(renderLines(linkStyle, wrapped[ref.Start:ref.End]))
+		pos = ref.End
+	}
+	out.WriteString(renderLines(plain, wrapped[pos:]))
+
+	if style.GetMarginTop() == 0 && style.GetMarginBottom() == 0 {
+		b.WriteString(out.String())
+		return
+	}
+	margins := lipgloss.NewStyle().MarginTop(style.GetMarginTop()).MarginBottom(style.GetMarginBottom())
+	b.WriteString(margins.Render(out.String()))
+}
+
+// writeLink writes a link to an item on its own, e.g. as an entry in a
+// "Used in" list
+func (m Model) writeLink(b *strings.Builder, kind store.RefKind, id, text string) {
+	style := m.addLink(store.Reference{Kind: kind, ID: id}, strings.Count(b.String(), "\n"))
+	b.WriteString(style.Render(text))
+}
+
+// addLink records a link on the given line and returns the style to render
+// it with, depending on whether it is selected
+func (m Model) addLink(ref store.Reference, line int) lipgloss.Style {
+	if m.links == nil {
+		return LinkStyle
+	}
+	style := LinkStyle
+	if len(m.links.refs) == m.linkIndex {
+		style = SelectedLinkStyle
+	}
+	m.links.refs = append(m.links.refs, ref)
+	m.links.lines = append(m.links.lines, line)
+	return style
+}
+
+// renderLines styles each line separately, so that styling part of a line
+// does not pad it to the width of the others
+func renderLines(style lipgloss.Style, text string) string {
+	if text == "" {
+		return ""
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = style.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// refItem returns the list item a cross-reference points to
+func (m Model) refItem(ref store.Reference) (list.Item, bool) {
+	switch ref.Kind {
+	case store.RefRequirement:
+		if r, ok := m.store.Requirement(ref.ID); ok {
+			return model.RequirementItem{Requirement: r}, true
+		}
+	case store.RefIndicator:
+		if ind, ok := m.store.Indicator(ref.ID); ok {
+			return model.IndicatorItem{Indicator: ind}, true
+		}
+	case store.RefDefinition:
+		if d, ok := m.store.Definition(ref.ID); ok {
+			return model.DefinitionItem{Definition: d}, true
+		}
+	case store.RefControl:
+		if c, ok := m.controlItem(ref.ID); ok {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// renderDetail renders the selected item into the viewport, recording its
+// cross-references
+func (m *Model) renderDetail() {
+	m.links = &detailLinks{}
+	m.viewport.SetContent(m.renderDetailContent())
+}
+
+// cycleLink selects the next (or previous) cross-reference and scrolls it into view
+func (m *Model) cycleLink(delta int) {
+	if m.links == nil || len(m.links.refs) == 0 {
+		return
+	}
+	n := len(m.links.refs)
+	if m.linkIndex < 0 && delta < 0 {
+		m.linkIndex = n - 1
+	} else {
+		m.linkIndex = ((m.linkIndex+delta)%n + n) % n
+	}
+	m.renderDetail()
+
+	line := m.links.lines[m.linkIndex]
+	if line < m.viewport.YOffset || line >= m.viewport.YOffset+m.viewport.Height {
+		m.viewport.SetYOffset(max(0, line-m.viewport.Height/2))
+	}
+}
+
+// followLink opens the selected cross-reference, remembering where we came from
+func (m *Model) followLink() bool {
+	if m.links == nil || m.linkIndex < 0 || m.linkIndex >= len(m.links.refs) {
+		return false
+	}
+	item, ok := m.refItem(m.links.refs[m.linkIndex])
+	if !ok {
+		return false
+	}
+	m.history = append(m.history, detailState{item: m.selectedItem, offset: m.viewport.YOffset, linkIndex: m.linkIndex})
+	m.openDetail(item)
+	return true
+}
+
+// goBack returns to the detail view a cross-reference was followed from
+func (m *Model) goBack() bool {
+	if len(m.history) == 0 {
+		return false
+	}
+	prev := m.history[len(m.history)-1]
+	m.history = m.history[:len(m.history)-1]
+	m.openDetail(prev.item)
+	m.linkIndex = prev.linkIndex
+	m.renderDetail()
+	m.viewport.SetYOffset(prev.offset)
+	return true
+}
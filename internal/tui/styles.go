@@ -1,6 +1,12 @@
 package tui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+)
 
 // Color palette
 var (
@@ -98,6 +104,25 @@ var (
 	ControlStyle = lipgloss.NewStyle().
 			Foreground(SecondaryColor)
 
+	WarningBannerStyle = lipgloss.NewStyle().
+				Foreground(BlackColor).
+				Background(WarningColor).
+				Bold(true).
+				Padding(0, 1)
+
+	// Cross-reference styles
+	LinkStyle         = lipgloss.NewStyle().Foreground(PrimaryColor).Underline(true)
+	SelectedLinkStyle = lipgloss.NewStyle().Foreground(BlackColor).Background(PrimaryColor).Bold(true)
+
+	// Requirement category styles
+	CategoryStyle       = lipgloss.NewStyle().Foreground(PrimaryColor)
+	CategoryHeaderStyle = lipgloss.NewStyle().Foreground(PrimaryColor).Bold(true).Underline(true)
+
+	// Load status styles
+	LoadOKStyle     = lipgloss.NewStyle().Foreground(SecondaryColor)
+	LoadWarnStyle   = lipgloss.NewStyle().Foreground(WarningColor)
+	LoadFailedStyle = lipgloss.NewStyle().Foreground(ErrorColor)
+
 	// Impact text styles (colored text, no background)
 	ImpactHighStyle = lipgloss.NewStyle().Foreground(ErrorColor).Bold(true)
 	ImpactModStyle  = lipgloss.NewStyle().Foreground(WarningColor).Bold(true)
@@ -175,6 +200,29 @@ func RetiredBadge() string {
 		Render("RETIRED")
 }
 
+func StaleBadge(age string) string {
+	return lipgloss.NewStyle().
+		Foreground(BlackColor).
+		Background(WarningColor).
+		Padding(0, 1).
+		Bold(true).
+		Render("STALE " + age)
+}
+
+// CommentStatusBadge shows whether an RFC is open for comment on the given day
+func CommentStatusBadge(rfc model.RFC, now time.Time) string {
+	style := lipgloss.NewStyle().Foreground(BlackColor).Padding(0, 1).Bold(true)
+	switch rfc.Status(now) {
+	case model.CommentOpen:
+		return style.Background(SecondaryColor).Render(fmt.Sprintf("OPEN %dd left", rfc.DaysLeft(now)))
+	case model.CommentUpcoming:
+		return style.Background(WarningColor).Render("UPCOMING")
+	case model.CommentClosed:
+		return DimStyle.Render("closed")
+	}
+	return ""
+}
+
 func ViewBadge(name string, active bool) string {
 	style := lipgloss.NewStyle().Padding(0, 1)
 	if active {
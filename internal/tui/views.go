@@ -3,9 +3,13 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/ethanolivertroy/fedramp-tui/internal/api"
+	"github.com/ethanolivertroy/fedramp-tui/internal/catalog"
 	"github.com/ethanolivertroy/fedramp-tui/internal/model"
+	"github.com/ethanolivertroy/fedramp-tui/internal/store"
 )
 
 // renderHeader renders the view navigation bar
@@ -19,20 +23,186 @@ func (m Model) renderHeader() string {
 		{"2", "Requirements", ViewRequirements},
 		{"3", "Definitions", ViewDefinitions},
 		{"4", "Indicators", ViewIndicators},
+		{"5", "RFCs", ViewRFCs},
+		{"6", "Controls", ViewControls},
 	}
 
 	var tabs []string
 	for _, v := range views {
 		active := m.view == v.state
 		tab := fmt.Sprintf("[%s] %s", v.key, v.name)
+		// Flag RFCs that can still be commented on
+		if v.state == ViewRFCs {
+			if n := m.openRFCCount(); n > 0 {
+				tab += fmt.Sprintf(" (%d open)", n)
+			}
+		}
 		tabs = append(tabs, ViewBadge(tab, active))
 	}
 
-	return lipgloss.JoinHorizontal(lipgloss.Left, tabs...) + "\n\n"
+	// Show where the data comes from so it can be cited by version
+	if m.apiClient != nil {
+		tabs = append(tabs, "  "+DimStyle.Render(m.apiClient.SourceLabel()))
+	}
+
+	header := lipgloss.JoinHorizontal(lipgloss.Left, tabs...) + "\n"
+	for _, banner := range []string{m.renderSnapshotBanner(), m.renderStaleBanner(), m.renderWarningBanner()} {
+		if banner != "" {
+			header += banner + "\n"
+		}
+	}
+	return header + "\n"
+}
+
+// renderSnapshotBanner warns that embedded offline data is being shown
+func (m Model) renderSnapshotBanner() string {
+	var codes []string
+	for _, code := range m.order {
+		if res, ok := m.results[code]; ok && res.Origin == api.OriginSnapshot {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return ""
+	}
+	release := m.snapshotRelease
+	if release == "" {
+		release = "unknown release"
+	}
+	scope := "all documents"
+	if len(codes) < len(m.store.Documents()) {
+		scope = strings.Join(codes, ", ")
+	}
+	return WarningBannerStyle.Render(fmt.Sprintf("OFFLINE: showing embedded data from %s for %s", release, scope))
+}
+
+// renderStaleBanner warns that some documents are served from expired cache entries
+func (m Model) renderStaleBanner() string {
+	codes := m.staleCodes()
+	if len(codes) == 0 {
+		return ""
+	}
+	parts := make([]string, len(codes))
+	for i, code := range codes {
+		parts[i] = fmt.Sprintf("%s (%s old)", code, formatAge(m.results[code].FetchedAt))
+	}
+	return WarningBannerStyle.Render(fmt.Sprintf("STALE: refresh failed for %s; retrying in background", strings.Join(parts, ", ")))
+}
+
+// renderWarningBanner lists the documents with program versions whose
+// effective status carries warnings. A document's detail view shows the
+// warnings in full.
+func (m Model) renderWarningBanner() string {
+	var parts []string
+	for _, doc := range m.store.Documents() {
+		var versions []string
+		for _, eff := range doc.EffectiveInfo {
+			if len(eff.Warnings) > 0 {
+				versions = append(versions, eff.Version)
+			}
+		}
+		if len(versions) > 0 {
+			parts = append(parts, fmt.Sprintf("%s (%s)", doc.Code, strings.Join(versions, ", ")))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return WarningBannerStyle.Render(fmt.Sprintf("WARNING: program status warnings for %s", strings.Join(parts, ", ")))
+}
+
+// loadStatus describes how a document was loaded, for the load status panel
+func loadStatus(res api.DocumentResult) (string, lipgloss.Style) {
+	switch {
+	case res.Error != nil:
+		return "fetch failed", LoadFailedStyle
+	case res.ParseError != nil:
+		return "parse failed", LoadFailedStyle
+	}
+	switch res.Origin {
+	case api.OriginCache:
+		return "cache", DimStyle
+	case api.OriginRevalidated:
+		return "revalidated", LoadOKStyle
+	case api.OriginStale:
+		return "stale cache", LoadWarnStyle
+	case api.OriginSnapshot:
+		return "snapshot", LoadWarnStyle
+	default:
+		return "fetched", LoadOKStyle
+	}
+}
+
+// renderLoadStatus renders the per-document load status panel shown on the
+// Documents view, with the error text of any document that failed
+func (m Model) renderLoadStatus() string {
+	if len(m.results) == 0 {
+		return ""
+	}
+
+	width := m.width - 6
+	if width <= 0 {
+		width = 80
+	}
+
+	var b strings.Builder
+	var line string
+	var failures []string
+	for _, code := range m.order {
+		res, ok := m.results[code]
+		if !ok {
+			continue
+		}
+		label, style := loadStatus(res)
+		entry := DocumentBadge(code) + " " + style.Render(label)
+		if n := len(res.Validation.Violations); n > 0 {
+			entry += LoadWarnStyle.Render(", " + plural(n, "schema issue"))
+		}
+		if line != "" && lipgloss.Width(line)+2+lipgloss.Width(entry) > width {
+			b.WriteString(line + "\n")
+			line = ""
+		}
+		if line != "" {
+			line += "  "
+		}
+		line += entry
+
+		switch {
+		case res.Error != nil:
+			failures = append(failures, fmt.Sprintf("%s: %v", code, res.Error))
+		case res.ParseError != nil:
+			failures = append(failures, fmt.Sprintf("%s: %v", code, res.ParseError))
+		}
+	}
+	b.WriteString(line)
+
+	for _, failure := range failures {
+		b.WriteString("\n")
+		b.WriteString(LoadFailedStyle.Render(truncate(failure, width)))
+	}
+	if len(failures) > 0 {
+		b.WriteString("\n")
+		if m.retrying {
+			b.WriteString(DimStyle.Render("Retrying failed documents..."))
+		} else {
+			b.WriteString(DimStyle.Render("r: retry failed documents"))
+		}
+	}
+	if issues, documents := m.schemaIssueCounts(); issues > 0 {
+		b.WriteString("\n")
+		b.WriteString(LoadWarnStyle.Render(plural(issues, "schema issue") + " in " + plural(documents, "document")))
+		b.WriteString(DimStyle.Render(" - d: diagnostics"))
+	}
+
+	return b.String() + "\n"
 }
 
 // renderDetailContent returns the content for the detail view (used by viewport)
 func (m Model) renderDetailContent() string {
+	// Links are recorded afresh on every render
+	if m.links != nil {
+		*m.links = detailLinks{}
+	}
 	switch item := m.selectedItem.(type) {
 	case model.RequirementItem:
 		return m.renderRequirementDetail(item)
@@ -42,6 +212,16 @@ func (m Model) renderDetailContent() string {
 		return m.renderIndicatorDetail(item)
 	case model.DocumentItem:
 		return m.renderDocumentDetail(item)
+	case model.CategoryItem:
+		return m.renderCategoryDetail(item)
+	case model.RFCItem:
+		return m.renderRFCDetail(item)
+	case model.CatalogControlItem:
+		return m.renderControlDetail(item)
+	case model.ControlFamilyItem:
+		return m.renderFamilyDetail(item)
+	case diagnosticsItem:
+		return m.renderDiagnostics()
 	}
 	return ""
 }
@@ -55,7 +235,18 @@ func (m Model) renderDetailView() string {
 	}
 
 	b.WriteString("\n")
-	b.WriteString(HelpStyle.Render("↑/↓/j/k scroll • q/ESC back"))
+	help := "↑/↓/j/k scroll • q/ESC back"
+	if m.links != nil && len(m.links.refs) > 0 {
+		help = "↑/↓/j/k scroll • tab/shift+tab references • enter follow • q/ESC back"
+	}
+	if _, ok := m.selectedItem.(model.DocumentItem); ok {
+		help += " • e/E export Markdown/HTML"
+	}
+	if m.detailNotice != "" {
+		b.WriteString(m.detailNotice)
+		b.WriteString("\n")
+	}
+	b.WriteString(HelpStyle.Render(help))
 
 	return AppStyle.Render(b.String())
 }
@@ -80,12 +271,65 @@ func (m Model) renderRequirementDetail(r model.RequirementItem) string {
 	b.WriteString(DetailValueStyle.Render(r.ID))
 	b.WriteString("\n")
 
+	// Category
+	if label := r.Category.Label(); label != "" {
+		b.WriteString(DetailLabelStyle.Render("Category:"))
+		b.WriteString(CategoryStyle.Render(label))
+		b.WriteString("\n")
+		if r.Category.Application != "" {
+			b.WriteString(DimStyle.Render(wrapText(r.Category.Application, m.width-10)))
+			b.WriteString("\n")
+		}
+	}
+
+	// Parent requirement
+	if r.ParentID != "" {
+		b.WriteString(DetailLabelStyle.Render("Part of:"))
+		if parent, ok := m.store.Requirement(r.ParentID); ok {
+			m.writeLink(&b, store.RefRequirement, parent.ID, parent.ID)
+			if parent.Name != "" {
+				b.WriteString(DimStyle.Render(" " + parent.Name))
+			}
+		} else {
+			b.WriteString(ControlStyle.Render(r.ParentID))
+		}
+		b.WriteString("\n")
+	}
+
 	// Statement
 	b.WriteString(DetailLabelStyle.Render("Statement:"))
 	b.WriteString("\n")
-	b.WriteString(StatementStyle.Render(wrapText(r.Statement, m.width-10)))
+	m.writeLinked(&b, r.Statement, m.width-10, StatementStyle)
 	b.WriteString("\n")
 
+	// Items that follow the statement, e.g. "MUST include the following:"
+	for _, text := range r.Following {
+		b.WriteString(DimStyle.Render("  • "))
+		m.writeLinked(&b, text, m.width-14, DetailValueStyle)
+		b.WriteString("\n")
+	}
+	if len(r.Children) > 0 {
+		b.WriteString("\n")
+		b.WriteString(DetailLabelStyle.Render(fmt.Sprintf("Includes (%d):", len(r.Children))))
+		b.WriteString("\n")
+		for _, id := range r.Children {
+			child, ok := m.store.Requirement(id)
+			if !ok {
+				b.WriteString(ControlStyle.Render("  "+id) + "\n")
+				continue
+			}
+			b.WriteString("  ")
+			m.writeLink(&b, store.RefRequirement, id, id)
+			if child.PrimaryKeyWord != "" {
+				b.WriteString(" ")
+				b.WriteString(KeywordIndicator(child.PrimaryKeyWord))
+			}
+			b.WriteString("\n")
+			b.WriteString(DetailValueStyle.Render("    " + wrapText(child.Statement, m.width-14)))
+			b.WriteString("\n")
+		}
+	}
+
 	// Affects
 	if len(r.Affects) > 0 {
 		b.WriteString(DetailLabelStyle.Render("Affects:"))
@@ -98,7 +342,51 @@ func (m Model) renderRequirementDetail(r model.RequirementItem) string {
 		b.WriteString("\n")
 		b.WriteString(DetailLabelStyle.Render("Note:"))
 		b.WriteString("\n")
-		b.WriteString(NoteStyle.Render(wrapText(r.Note, m.width-10)))
+		m.writeLinked(&b, r.Note, m.width-10, NoteStyle)
+	}
+
+	return b.String()
+}
+
+// renderCategoryDetail shows a category's application and its requirements
+func (m Model) renderCategoryDetail(c model.CategoryItem) string {
+	var b strings.Builder
+
+	b.WriteString(DetailTitleStyle.Render(c.Label()))
+	b.WriteString("\n")
+	b.WriteString(DocumentBadge(c.DocumentCode))
+	b.WriteString("\n\n")
+
+	b.WriteString(DetailLabelStyle.Render("ID:"))
+	b.WriteString(DetailValueStyle.Render(c.ID))
+	b.WriteString("\n")
+
+	if c.Application != "" {
+		b.WriteString(DetailLabelStyle.Render("Application:"))
+		b.WriteString("\n")
+		b.WriteString(StatementStyle.Render(wrapText(c.Application, m.width-10)))
+		b.WriteString("\n")
+	}
+
+	var reqs []model.Requirement
+	for _, r := range m.store.DocumentRequirements(c.DocumentCode) {
+		if r.Category.ID == c.ID {
+			reqs = append(reqs, r)
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString(DetailLabelStyle.Render(fmt.Sprintf("Requirements (%d):", len(reqs))))
+	b.WriteString("\n")
+	for _, r := range reqs {
+		b.WriteString(ControlStyle.Render("  " + r.ID))
+		if r.PrimaryKeyWord != "" {
+			b.WriteString(" ")
+			b.WriteString(KeywordIndicator(r.PrimaryKeyWord))
+		}
+		if r.Name != "" {
+			b.WriteString(DetailValueStyle.Render(" " + r.Name))
+		}
+		b.WriteString("\n")
 	}
 
 	return b.String()
@@ -127,14 +415,14 @@ func (m Model) renderDefinitionDetail(d model.DefinitionItem) string {
 	b.WriteString("\n")
 	b.WriteString(DetailLabelStyle.Render("Definition:"))
 	b.WriteString("\n")
-	b.WriteString(StatementStyle.Render(wrapText(d.Text, m.width-10)))
+	m.writeLinked(&b, d.Text, m.width-10, StatementStyle)
 
 	// Note
 	if d.Note != "" {
 		b.WriteString("\n\n")
 		b.WriteString(DetailLabelStyle.Render("Note:"))
 		b.WriteString("\n")
-		b.WriteString(NoteStyle.Render(wrapText(d.Note, m.width-10)))
+		m.writeLinked(&b, d.Note, m.width-10, NoteStyle)
 	}
 
 	// Reference
@@ -150,6 +438,27 @@ func (m Model) renderDefinitionDetail(d model.DefinitionItem) string {
 		}
 	}
 
+	// Requirements and indicators that use the term
+	usages := m.store.Usages(d.ID)
+	b.WriteString("\n\n")
+	b.WriteString(DetailLabelStyle.Render(fmt.Sprintf("Used in (%d):", len(usages))))
+	for _, u := range usages {
+		b.WriteString("\n  ")
+		m.writeLink(&b, u.Kind, u.ID, u.ID)
+		name := ""
+		switch u.Kind {
+		case store.RefRequirement:
+			r, _ := m.store.Requirement(u.ID)
+			name = r.Name
+		case store.RefIndicator:
+			ind, _ := m.store.Indicator(u.ID)
+			name = ind.Name
+		}
+		if name != "" {
+			b.WriteString(DimStyle.Render(" " + name))
+		}
+	}
+
 	return b.String()
 }
 
@@ -185,7 +494,7 @@ func (m Model) renderIndicatorDetail(ind model.IndicatorItem) string {
 	b.WriteString("\n")
 	b.WriteString(DetailLabelStyle.Render("Statement:"))
 	b.WriteString("\n")
-	b.WriteString(StatementStyle.Render(wrapText(ind.Statement, m.width-10)))
+	m.writeLinked(&b, ind.Statement, m.width-10, StatementStyle)
 
 	// Controls
 	if len(ind.Controls) > 0 {
@@ -193,7 +502,10 @@ func (m Model) renderIndicatorDetail(ind model.IndicatorItem) string {
 		b.WriteString(DetailLabelStyle.Render(fmt.Sprintf("SP 800-53 Controls (%d):", len(ind.Controls))))
 		b.WriteString("\n")
 		for _, ctrl := range ind.Controls {
-			b.WriteString(ControlStyle.Render(fmt.Sprintf("  %s: %s", ctrl.ControlID, ctrl.Title)))
+			// Controls open with their coverage and, from the catalog, full text
+			b.WriteString("  ")
+			m.writeLink(&b, store.RefControl, ctrl.ControlID, catalog.LabelFor(ctrl.ControlID))
+			b.WriteString(ControlStyle.Render(": " + ctrl.Title))
 			b.WriteString("\n")
 		}
 	}
@@ -203,7 +515,7 @@ func (m Model) renderIndicatorDetail(ind model.IndicatorItem) string {
 		b.WriteString("\n")
 		b.WriteString(DetailLabelStyle.Render("Note:"))
 		b.WriteString("\n")
-		b.WriteString(NoteStyle.Render(wrapText(ind.Note, m.width-10)))
+		m.writeLinked(&b, ind.Note, m.width-10, NoteStyle)
 	}
 
 	// Reference
@@ -228,16 +540,61 @@ func (m Model) renderDocumentDetail(d model.DocumentItem) string {
 	b.WriteString(DetailTitleStyle.Render(d.Name))
 	b.WriteString("\n")
 	b.WriteString(DocumentBadge(d.Code))
+	if d.Stale {
+		b.WriteString(" ")
+		b.WriteString(StaleBadge(formatAge(d.FetchedAt)))
+	}
 	b.WriteString("\n\n")
 
+	// Effective-status warnings, before anything else
+	if warnings := d.Warnings(); len(warnings) > 0 {
+		b.WriteString(WarningBannerStyle.Render("WARNINGS"))
+		b.WriteString("\n")
+		for _, w := range warnings {
+			b.WriteString(NoteStyle.Render(wrapIndented("  ! ", w, m.width-14)))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	// Stale data warning
+	if d.Stale {
+		b.WriteString(NoteStyle.Render(fmt.Sprintf("Showing cached data from %s; the latest refresh failed.", d.FetchedAt.Format("2006-01-02 15:04"))))
+		if res, ok := m.results[d.Code]; ok && res.RefreshError != nil {
+			b.WriteString("\n")
+			b.WriteString(DimStyle.Render(wrapText(res.RefreshError.Error(), m.width-10)))
+		}
+		b.WriteString("\n\n")
+	}
+
 	// Basic info
 	b.WriteString(DetailLabelStyle.Render("Description:"))
 	b.WriteString(DetailValueStyle.Render(d.Document.Description))
 	b.WriteString("\n")
 
-	if d.RequirementCount > 0 {
-		b.WriteString(DetailLabelStyle.Render("Requirements:"))
-		b.WriteString(DetailValueStyle.Render(fmt.Sprintf("%d", d.RequirementCount)))
+	for _, count := range []struct {
+		label string
+		n     int
+	}{
+		{"Requirements:", d.RequirementCount},
+		{"Indicators:", d.IndicatorCount},
+		{"Definitions:", d.DefinitionCount},
+	} {
+		if count.n > 0 {
+			b.WriteString(DetailLabelStyle.Render(count.label))
+			b.WriteString(DetailValueStyle.Render(fmt.Sprintf("%d", count.n)))
+			b.WriteString("\n")
+		}
+	}
+
+	if res, ok := m.results[d.Code]; ok && res.Error == nil {
+		b.WriteString(DetailLabelStyle.Render("Schema:"))
+		if n := len(res.Validation.Violations); n > 0 {
+			b.WriteString(LoadWarnStyle.Render(plural(n, "issue")))
+			b.WriteString(DimStyle.Render(" (d on Documents view for details)"))
+		} else {
+			b.WriteString(LoadOKStyle.Render("valid"))
+		}
 		b.WriteString("\n")
 	}
 
@@ -267,8 +624,8 @@ func (m Model) renderDocumentDetail(d model.DocumentItem) string {
 		b.WriteString("\n")
 		b.WriteString(DetailLabelStyle.Render("Program Status:"))
 		b.WriteString("\n")
-		for version, eff := range d.Document.EffectiveInfo {
-			b.WriteString(ControlStyle.Render(fmt.Sprintf("  %s: ", version)))
+		for _, eff := range d.Document.EffectiveInfo {
+			b.WriteString(ControlStyle.Render(fmt.Sprintf("  %s: ", eff.Version)))
 			b.WriteString(DetailValueStyle.Render(eff.Is))
 			if eff.CurrentStatus != "" {
 				b.WriteString(DimStyle.Render(fmt.Sprintf(" (%s)", eff.CurrentStatus)))
@@ -281,6 +638,10 @@ func (m Model) renderDocumentDetail(d model.DocumentItem) string {
 				}
 				b.WriteString("\n")
 			}
+			for _, w := range eff.Warnings {
+				b.WriteString(NoteStyle.Render(wrapIndented("    Warning: ", w, m.width-16)))
+				b.WriteString("\n")
+			}
 		}
 	}
 
@@ -301,34 +662,44 @@ func (m Model) renderDocumentDetail(d model.DocumentItem) string {
 				b.WriteString(DetailURLStyle.Render(fmt.Sprintf("    %s", auth.ReferenceURL)))
 				b.WriteString("\n")
 			}
+			if auth.Delegation != "" {
+				b.WriteString(DimStyle.Render("    Delegation: "))
+				b.WriteString(DetailValueStyle.Render(wrapText(auth.Delegation, m.width-26)))
+				b.WriteString("\n")
+			}
+			if auth.DelegationURL != "" {
+				b.WriteString(DetailURLStyle.Render(fmt.Sprintf("    %s", auth.DelegationURL)))
+				b.WriteString("\n")
+			}
 		}
 	}
 
-	// Releases
+	// Releases, with their related RFCs
 	if len(d.Document.Releases) > 0 {
 		b.WriteString("\n")
 		b.WriteString(DetailLabelStyle.Render(fmt.Sprintf("Releases (%d):", len(d.Document.Releases))))
 		b.WriteString("\n")
-		// Show latest 3 releases
-		maxReleases := 3
-		if len(d.Document.Releases) < maxReleases {
-			maxReleases = len(d.Document.Releases)
-		}
-		for i := 0; i < maxReleases; i++ {
-			rel := d.Document.Releases[i]
+		for _, rel := range d.Document.Releases {
 			b.WriteString(ControlStyle.Render(fmt.Sprintf("  %s", rel.ID)))
 			if rel.PublishedDate != "" {
 				b.WriteString(DimStyle.Render(fmt.Sprintf(" (%s)", rel.PublishedDate)))
 			}
+			if rel.PublicComment {
+				b.WriteString(" ")
+				b.WriteString(LoadWarnStyle.Render("public comment"))
+			}
 			b.WriteString("\n")
 			if rel.Description != "" {
 				b.WriteString(DimStyle.Render(fmt.Sprintf("    %s", wrapText(rel.Description, m.width-14))))
 				b.WriteString("\n")
 			}
-		}
-		if len(d.Document.Releases) > maxReleases {
-			b.WriteString(DimStyle.Render(fmt.Sprintf("  ... and %d more releases", len(d.Document.Releases)-maxReleases)))
-			b.WriteString("\n")
+			for _, rfc := range rel.RelatedRFCs {
+				b.WriteString(DimStyle.Render("    RFC "))
+				b.WriteString(DetailValueStyle.Render(rfc.ID + " " + rfc.Title()))
+				b.WriteString(" ")
+				b.WriteString(CommentStatusBadge(rfc, m.now()))
+				b.WriteString("\n")
+			}
 		}
 	}
 
@@ -338,6 +709,85 @@ func (m Model) renderDocumentDetail(d model.DocumentItem) string {
 	return b.String()
 }
 
+// renderRFCDetail shows an RFC's comment window and where it is referenced
+func (m Model) renderRFCDetail(r model.RFCItem) string {
+	var b strings.Builder
+	now := m.now()
+
+	b.WriteString(DetailTitleStyle.Render(r.RFC.Title()))
+	b.WriteString("\n")
+	b.WriteString(CommentStatusBadge(r.RFC, now))
+	for _, code := range r.DocumentCodes {
+		b.WriteString(" ")
+		b.WriteString(DocumentBadge(code))
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(DetailLabelStyle.Render("ID:"))
+	b.WriteString(DetailValueStyle.Render(r.ID))
+	b.WriteString("\n")
+	if r.ShortName != "" && r.ShortName != r.RFC.Title() {
+		b.WriteString(DetailLabelStyle.Render("Short Name:"))
+		b.WriteString(DetailValueStyle.Render(r.ShortName))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(DetailLabelStyle.Render("Comment Period:"))
+	b.WriteString(DetailValueStyle.Render(fmt.Sprintf("%s to %s", r.StartDate, r.EndDate)))
+	b.WriteString("\n")
+	switch r.Status(now) {
+	case model.CommentOpen:
+		b.WriteString(LoadOKStyle.Render(fmt.Sprintf("Open for comment: %d days left", r.DaysLeft(now))))
+		b.WriteString("\n")
+	case model.CommentUpcoming:
+		b.WriteString(LoadWarnStyle.Render(fmt.Sprintf("Comments open on %s", r.StartDate)))
+		b.WriteString("\n")
+	}
+
+	if len(r.ReleaseIDs) > 0 {
+		b.WriteString(DetailLabelStyle.Render("Releases:"))
+		b.WriteString(DetailValueStyle.Render(strings.Join(r.ReleaseIDs, ", ")))
+		b.WriteString("\n")
+	}
+
+	if r.URL != "" {
+		b.WriteString("\n")
+		b.WriteString(DetailLabelStyle.Render("RFC:"))
+		b.WriteString(DetailURLStyle.Render(r.URL))
+		b.WriteString("\n")
+	}
+	if r.DiscussionURL != "" {
+		b.WriteString(DetailLabelStyle.Render("Discussion:"))
+		b.WriteString(DetailURLStyle.Render(r.DiscussionURL))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// formatAge returns a compact age such as "45m", "5h" or "3d"
+func formatAge(t time.Time) string {
+	if t.IsZero() {
+		return "unknown"
+	}
+	d := time.Since(t)
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// wrapIndented wraps text to width after prefix, indenting continuation
+// lines so they line up under the text rather than the prefix
+func wrapIndented(prefix, text string, width int) string {
+	indent := strings.Repeat(" ", lipgloss.Width(prefix))
+	return prefix + strings.ReplaceAll(wrapText(text, width), "\n", "\n"+indent)
+}
+
 // wrapText wraps text to the specified width
 func wrapText(text string, width int) string {
 	if width <= 0 {
@@ -1,25 +1,64 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethanolivertroy/fedramp-tui/internal/api"
+	"github.com/ethanolivertroy/fedramp-tui/internal/catalog"
+	"github.com/ethanolivertroy/fedramp-tui/internal/cli"
+	"github.com/ethanolivertroy/fedramp-tui/internal/config"
 	"github.com/ethanolivertroy/fedramp-tui/internal/tui"
 )
 
 func main() {
-	refresh := flag.Bool("refresh", false, "Force fresh fetch, ignoring cache")
+	refresh := flag.Bool("refresh", false, "Revalidate cached documents with their source now")
+	source := flag.String("source", "", "Document source: URL, directory of FRMR.*.json files, or .tar.gz archive (default: FedRAMP/docs on GitHub)")
+	ref := flag.String("ref", "", "FedRAMP/docs branch, tag or commit SHA to load (default: main)")
+	catalogPath := flag.String("catalog", "", "OSCAL SP 800-53 catalog JSON file for control details (default: bundled copy, if any)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [command]\n\nFlags:\n", os.Args[0])
+		flag.PrintDefaults()
+		fmt.Fprintln(flag.CommandLine.Output())
+		cli.Usage(flag.CommandLine.Output())
+	}
 	flag.Parse()
 
-	var opts []tui.ModelOption
-	if *refresh {
-		opts = append(opts, tui.WithRefresh(true))
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: reading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	clientOpts, err := sourceOptions(cfg, *source, *ref)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	clientOpts = append(clientOpts, api.WithRefresh(*refresh))
+	client := api.NewClient(clientOpts...)
+
+	// Subcommands run without the TUI
+	if flag.NArg() > 0 {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		code := cli.Run(ctx, client, flag.Args(), os.Stdout, os.Stderr)
+		stop()
+		os.Exit(code)
+	}
+
+	cat, err := loadCatalog(cfg, *catalogPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
 	p := tea.NewProgram(
-		tui.NewModel(opts...),
+		tui.NewModel(tui.WithClient(client), tui.WithCatalog(cat)),
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
 	)
@@ -29,3 +68,49 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// loadCatalog loads the SP 800-53 catalog named by the flag or config, or the
+// bundled copy. Without either, control details are unavailable.
+func loadCatalog(cfg *config.Config, path string) (*catalog.Catalog, error) {
+	if path == "" {
+		path = cfg.Catalog
+	}
+	if path != "" {
+		cat, err := catalog.Load(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --catalog: %w", err)
+		}
+		return cat, nil
+	}
+	cat, err := catalog.Bundled()
+	if errors.Is(err, catalog.ErrNotBundled) {
+		return nil, nil
+	}
+	return cat, err
+}
+
+// sourceOptions selects the document source from flags and config. A flag
+// replaces both config settings, since a ref only applies to FedRAMP/docs.
+func sourceOptions(cfg *config.Config, source, ref string) ([]api.ClientOption, error) {
+	if source == "" && ref == "" {
+		source, ref = cfg.Source, cfg.Ref
+	}
+	if source != "" && ref != "" {
+		return nil, errors.New("--ref cannot be combined with --source")
+	}
+
+	switch {
+	case ref != "":
+		if err := api.ValidateRef(ref); err != nil {
+			return nil, err
+		}
+		return []api.ClientOption{api.WithRef(ref)}, nil
+	case source != "":
+		src, err := api.ParseSource(source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --source: %w", err)
+		}
+		return []api.ClientOption{api.WithSource(src)}, nil
+	}
+	return nil, nil
+}